@@ -1,12 +1,16 @@
 package main
 
 import (
+	"bufio"
 	"context"
+	"encoding/csv"
 	"encoding/json"
 	"encoding/xml"
 	"flag"
 	"fmt"
 	"os"
+	"strings"
+	"text/tabwriter"
 
 	"github.com/Epistemic-Technology/orcid/orcid"
 )
@@ -18,53 +22,128 @@ func main() {
 		orcidID     string
 		sandbox     bool
 		useXML      bool
+		format      string
 		raw         bool
+		works       bool
+		full        bool
+		all         bool
+		maxResults  int
+		inputFile   string
 		rows        int
 		start       int
 	)
 
-	flag.StringVar(&bearerToken, "token", "", "Bearer token for ORCID API authentication (required)")
+	flag.StringVar(&bearerToken, "token", "", "Bearer token for ORCID API authentication (default $ORCID_TOKEN)")
 	flag.StringVar(&bearerToken, "t", "", "Bearer token for ORCID API authentication (shorthand)")
 	flag.StringVar(&searchQuery, "query", "", "Search query string")
 	flag.StringVar(&searchQuery, "q", "", "Search query string (shorthand)")
 	flag.StringVar(&orcidID, "orcid", "", "ORCID ID to retrieve")
 	flag.StringVar(&orcidID, "o", "", "ORCID ID to retrieve (shorthand)")
+	flag.StringVar(&inputFile, "input", "", "File of ORCID IDs, one per line, to fetch as newline-delimited JSON")
 	flag.BoolVar(&sandbox, "sandbox", false, "Use ORCID sandbox instead of production")
 	flag.BoolVar(&useXML, "xml", false, "Output XML instead of JSON")
+	flag.StringVar(&format, "format", "", "Output format: json, xml, csv (for -works or search) or bibtex (for -works)")
 	flag.BoolVar(&raw, "raw", false, "Output raw response (only works with -o flag)")
+	flag.BoolVar(&works, "works", false, "List the works of the ORCID ID given with -o as a table")
+	flag.BoolVar(&full, "full", false, "Fetch full details of each work (with -works)")
 	flag.IntVar(&rows, "rows", 10, "Number of results to return (for search)")
 	flag.IntVar(&start, "start", 0, "Starting position for pagination (for search)")
+	flag.BoolVar(&all, "all", false, "Stream every search result, one per line, fetching -rows per page")
+	flag.IntVar(&maxResults, "max", 1000, "Maximum number of results to stream with -all (0 for no limit)")
+	flag.Usage = func() {
+		fmt.Fprintf(flag.CommandLine.Output(), "Usage of %s:\n", os.Args[0])
+		flag.PrintDefaults()
+		fmt.Fprintf(flag.CommandLine.Output(), "\nWithout -token, the token is read from ORCID_TOKEN, or obtained with the\n"+
+			"API client credentials in ORCID_CLIENT_ID and ORCID_CLIENT_SECRET.\n")
+	}
 	flag.Parse()
 
-	// Check for required parameters
+	// Fall back to the environment, which keeps the token out of shell
+	// history and process lists
 	if bearerToken == "" {
-		fmt.Fprintf(os.Stderr, "Error: Bearer token is required. Use -token or -t flag\n")
+		bearerToken = os.Getenv("ORCID_TOKEN")
+	}
+
+	// Check that exactly one of search query, orcid ID and input file is provided
+	modes := 0
+	for _, set := range []bool{searchQuery != "", orcidID != "", inputFile != ""} {
+		if set {
+			modes++
+		}
+	}
+	if modes == 0 {
+		fmt.Fprintf(os.Stderr, "Error: Either search query (-q), ORCID ID (-o) or input file (-input) is required\n")
+		flag.Usage()
+		os.Exit(1)
+	}
+	if modes > 1 {
+		fmt.Fprintf(os.Stderr, "Error: Only one of search query (-q), ORCID ID (-o) and input file (-input) can be used at a time\n")
 		flag.Usage()
 		os.Exit(1)
 	}
 
-	// Check that either search query or orcid ID is provided, but not both
-	if searchQuery == "" && orcidID == "" {
-		fmt.Fprintf(os.Stderr, "Error: Either search query (-q) or ORCID ID (-o) is required\n")
+	if works && orcidID == "" {
+		fmt.Fprintf(os.Stderr, "Error: -works requires an ORCID ID (-o)\n")
 		flag.Usage()
 		os.Exit(1)
 	}
 
-	if searchQuery != "" && orcidID != "" {
-		fmt.Fprintf(os.Stderr, "Error: Cannot use both search query (-q) and ORCID ID (-o) at the same time\n")
+	// -xml is shorthand for -format xml
+	if useXML && format != "" && format != "xml" {
+		fmt.Fprintf(os.Stderr, "Error: Cannot use -xml with -format %s\n", format)
+		flag.Usage()
+		os.Exit(1)
+	}
+	if useXML {
+		format = "xml"
+	}
+	useXML = format == "xml"
+	var formatErr string
+	switch format {
+	case "", "json", "xml":
+		if inputFile != "" && useXML {
+			formatErr = "-input only supports JSON output"
+		}
+	case "csv":
+		if !works && searchQuery == "" {
+			formatErr = "-format csv requires -works or a search (-q)"
+		}
+	case "bibtex":
+		if !works {
+			formatErr = "-format bibtex requires -works"
+		}
+	default:
+		formatErr = "unknown -format " + format
+	}
+	if formatErr != "" {
+		fmt.Fprintf(os.Stderr, "Error: %s\n", formatErr)
 		flag.Usage()
 		os.Exit(1)
 	}
 
+	ctx := context.Background()
+
+	// Mint a /read-public token from client credentials as a last resort
+	if bearerToken == "" {
+		clientID, clientSecret := os.Getenv("ORCID_CLIENT_ID"), os.Getenv("ORCID_CLIENT_SECRET")
+		if clientID == "" || clientSecret == "" {
+			fmt.Fprintf(os.Stderr, "Error: Bearer token is required. Use -token or -t flag, or set ORCID_TOKEN\n")
+			flag.Usage()
+			os.Exit(1)
+		}
+		token, err := orcid.GetReadPublicToken(ctx, clientID, clientSecret, sandbox)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error obtaining token from client credentials: %v\n", err)
+			os.Exit(1)
+		}
+		bearerToken = token.AccessToken
+	}
+
 	// Configure client options
 	var clientOpts []orcid.ClientOption
 
-	// Set API URL based on sandbox flag
-	if sandbox {
-		clientOpts = append(clientOpts, orcid.WithAPIURL(orcid.PublicSandboxHost))
-	} else {
-		clientOpts = append(clientOpts, orcid.WithAPIURL(orcid.PublicHost))
-	}
+	// Select the public API host based on sandbox flag
+	clientOpts = append(clientOpts, orcid.WithAPIType(orcid.APIPublic), orcid.WithSandbox(sandbox))
 
 	// Set content type based on xml flag
 	if useXML {
@@ -78,11 +157,37 @@ func main() {
 
 	// Create client
 	client := orcid.NewClient(clientOpts...)
-	ctx := context.Background()
 
 	var output []byte
 
-	if searchQuery != "" {
+	if inputFile != "" {
+		// Fetch every iD in the file
+		failed, err := fetchRecords(ctx, client, inputFile)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Error reading input file: %v\n", err)
+			os.Exit(1)
+		}
+		if failed > 0 {
+			os.Exit(1)
+		}
+	} else if searchQuery != "" && format == "csv" {
+		// Tabulate names, emails and institutions from the expanded search
+		if err := writeSearchCSV(ctx, client, searchQuery, start, rows, all, maxResults); err != nil {
+			fmt.Fprintf(os.Stderr, "Error performing search: %v\n", err)
+			os.Exit(1)
+		}
+	} else if searchQuery != "" && all {
+		// Stream every page of the search
+		params := orcid.SearchParams{
+			Query: searchQuery,
+			Start: start,
+			Rows:  rows,
+		}
+		if err := streamSearch(ctx, client, params, maxResults, useXML); err != nil {
+			fmt.Fprintf(os.Stderr, "Error performing search: %v\n", err)
+			os.Exit(1)
+		}
+	} else if searchQuery != "" {
 		// Perform search
 		params := orcid.SearchParams{
 			Query: searchQuery,
@@ -112,6 +217,12 @@ func main() {
 			}
 			fmt.Println(string(output))
 		}
+	} else if works {
+		// List works as a table unless another format was chosen
+		if err := printWorks(ctx, client, orcidID, full, format); err != nil {
+			fmt.Fprintf(os.Stderr, "Error retrieving works: %v\n", err)
+			os.Exit(1)
+		}
 	} else {
 		// Get ORCID record
 		if raw {
@@ -149,3 +260,217 @@ func main() {
 		}
 	}
 }
+
+// printWorks prints the works of orcidID, fetching the full works if full
+// is set and the summaries otherwise. With no format, the title, type, year
+// and DOI of each are printed as a table; csv prints the same columns. Full
+// works that loaded are printed even if others failed, and the error for
+// the failures is returned afterwards.
+func printWorks(ctx context.Context, client *orcid.Client, orcidID string, full bool, format string) error {
+	if !full {
+		works, err := client.GetWorks(ctx, orcidID)
+		if err != nil {
+			return err
+		}
+		return writeWorks(works, nil, format)
+	}
+
+	details, err := client.GetAllWorkDetails(ctx, orcidID, 4)
+	if err != nil && len(details) == 0 {
+		return err
+	}
+	if printErr := writeWorks(nil, details, format); printErr != nil {
+		return printErr
+	}
+	return err
+}
+
+// writeWorks prints the work summaries in works, or the full works in
+// details if works is nil, in the given format.
+func writeWorks(works *orcid.Works, details []*orcid.Work, format string) error {
+	full := works == nil
+	switch format {
+	case "json", "xml":
+		var v interface{} = works
+		if full {
+			v = &struct {
+				XMLName xml.Name      `json:"-" xml:"works"`
+				Work    []*orcid.Work `json:"work" xml:"work"`
+			}{Work: details}
+		}
+		return printMarshaled(v, format == "xml")
+	case "bibtex":
+		if !full {
+			bib, err := orcid.WorksToBibTeX(works)
+			if err != nil {
+				return err
+			}
+			fmt.Print(bib)
+			return nil
+		}
+		for _, work := range details {
+			bib, err := orcid.WorkToBibTeX(work)
+			if err != nil {
+				return err
+			}
+			fmt.Print(bib)
+		}
+		return nil
+	}
+
+	rows := [][]string{{"TITLE", "TYPE", "YEAR", "DOI"}}
+	if full {
+		for _, work := range details {
+			doi, _ := work.DOI()
+			rows = append(rows, workRow(work.Title, work.Type, work.PublicationDate, doi))
+		}
+	} else {
+		for _, summary := range works.PreferredSummaries() {
+			doi, _ := summary.DOI()
+			rows = append(rows, workRow(summary.Title, summary.Type, summary.PublicationDate, doi))
+		}
+	}
+
+	if format == "csv" {
+		rows[0] = []string{"title", "type", "year", "doi"}
+		w := csv.NewWriter(os.Stdout)
+		w.WriteAll(rows)
+		return w.Error()
+	}
+	w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, row := range rows {
+		fmt.Fprintln(w, strings.Join(row, "\t"))
+	}
+	return w.Flush()
+}
+
+func workRow(title *orcid.Title, workType orcid.WorkType, date *orcid.PublicationDate, doi string) []string {
+	var titleText, year string
+	if title != nil && title.Title != nil {
+		// Collapse line breaks and tabs, which would break the table
+		titleText = strings.Join(strings.Fields(title.Title.Value), " ")
+	}
+	if date != nil && date.Year != nil {
+		year = date.Year.Value
+	}
+	return []string{titleText, string(workType), year, doi}
+}
+
+// printMarshaled prints v as indented XML or JSON.
+func printMarshaled(v interface{}, useXML bool) error {
+	if useXML {
+		output, err := xml.MarshalIndent(v, "", "  ")
+		if err != nil {
+			return err
+		}
+		fmt.Println(xml.Header + string(output))
+		return nil
+	}
+	output, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return err
+	}
+	fmt.Println(string(output))
+	return nil
+}
+
+// writeSearchCSV prints the expanded search results for query as CSV: one
+// page of rows results from start, or with all every page up to maxResults.
+func writeSearchCSV(ctx context.Context, client *orcid.Client, query string, start, rows int, all bool, maxResults int) error {
+	params := orcid.ExpandedSearchParams{Query: query, Start: start, Rows: rows}
+	if !all {
+		result, err := client.ExpandedSearchWithParams(ctx, params)
+		if err != nil {
+			return err
+		}
+		return orcid.WriteSearchCSV(os.Stdout, result.ExpandedResults)
+	}
+
+	var results []*orcid.ExpandedSearchRecord
+	iter := client.ExpandedSearchIter(ctx, params)
+	for (maxResults == 0 || len(results) < maxResults) && iter.Next() {
+		results = append(results, iter.Value())
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	return orcid.WriteSearchCSV(os.Stdout, results)
+}
+
+// streamSearch prints the results of the search as they are fetched, one
+// per line, stopping after maxResults unless it is 0.
+func streamSearch(ctx context.Context, client *orcid.Client, params orcid.SearchParams, maxResults int, useXML bool) error {
+	iter := client.SearchIter(ctx, params).WithMaxResults(maxResults)
+	for iter.Next() {
+		var line []byte
+		var err error
+		if useXML {
+			line, err = xml.Marshal(iter.Value())
+		} else {
+			line, err = json.Marshal(iter.Value())
+		}
+		if err != nil {
+			return err
+		}
+		fmt.Println(string(line))
+	}
+	if err := iter.Error(); err != nil {
+		return err
+	}
+	if maxResults > 0 && iter.TotalResults() > params.Start+maxResults {
+		fmt.Fprintf(os.Stderr, "Stopped after %d of %d results; raise -max to see more\n", maxResults, iter.TotalResults())
+	}
+	return nil
+}
+
+// fetchRecords fetches the records of the iDs listed in path, one per line,
+// and prints them as newline-delimited JSON in file order. Lines that are
+// not valid iDs, and iDs whose record could not be fetched, are reported on
+// stderr without stopping the others; their number is returned.
+func fetchRecords(ctx context.Context, client *orcid.Client, path string) (int, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return 0, err
+	}
+	defer f.Close()
+
+	var ids []string
+	invalid := 0
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" {
+			continue
+		}
+		// Accept profile URLs and iDs without hyphens, as the API does not
+		id, err := orcid.ResolveOrcidID(text)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "Skipping line %d: %v\n", line, err)
+			invalid++
+			continue
+		}
+		ids = append(ids, id)
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, err
+	}
+
+	records, errs := client.GetRecords(ctx, ids, 0)
+	printed := make(map[string]bool, len(ids))
+	for _, id := range ids {
+		if printed[id] {
+			continue
+		}
+		printed[id] = true
+		if err, ok := errs[id]; ok {
+			fmt.Fprintf(os.Stderr, "Error retrieving %s: %v\n", id, err)
+			continue
+		}
+		line, err := json.Marshal(records[id])
+		if err != nil {
+			return 0, err
+		}
+		fmt.Println(string(line))
+	}
+	return invalid + len(errs), nil
+}
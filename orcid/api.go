@@ -0,0 +1,81 @@
+package orcid
+
+import (
+	"context"
+	"time"
+)
+
+// API is the set of ORCID operations provided by Client. Code that depends on
+// API rather than *Client can be tested with a fake implementation instead of
+// an HTTP server; *Client remains the implementation for real use.
+//
+// The iterators, OAuth token methods and client housekeeping such as Close
+// and Stats are deliberately left out, as they are tied to a live Client.
+type API interface {
+	// Records and person
+	GetRecord(ctx context.Context, orcidID string) (*Record, error)
+	GetRecordSummary(ctx context.Context, orcidID string) (*RecordSummary, error)
+	GetRecordResolved(ctx context.Context, orcidID string) (*Record, string, error)
+	GetRecordIfModified(ctx context.Context, orcidID string, since time.Time) (*Record, bool, error)
+	GetRecords(ctx context.Context, orcidIDs []string, concurrency int) (map[string]*Record, map[string]error)
+	GetRecordRaw(ctx context.Context, orcidID string) ([]byte, error)
+	GetPerson(ctx context.Context, orcidID string) (*Person, error)
+	GetActivities(ctx context.Context, orcidID string) (*ActivitiesSummary, error)
+	GetBiography(ctx context.Context, orcidID string) (*Biography, error)
+	GetKeywords(ctx context.Context, orcidID string) (*Keywords, error)
+	GetEmails(ctx context.Context, orcidID string) (*Emails, error)
+	GetByPath(ctx context.Context, path Path) (interface{}, error)
+	FetchAll(ctx context.Context, orcidID string, section string) (interface{}, error)
+
+	// Works
+	GetWorks(ctx context.Context, orcidID string) (*Works, error)
+	GetWork(ctx context.Context, orcidID string, putCode string) (*Work, error)
+	GetWorksByPutCodes(ctx context.Context, orcidID string, putCodes []int64) ([]*Work, error)
+	GetAllWorkDetails(ctx context.Context, orcidID string, concurrency int) ([]*Work, error)
+
+	// Affiliations and other activities
+	GetEducations(ctx context.Context, orcidID string) (*Educations, error)
+	GetEducation(ctx context.Context, orcidID string, putCode string) (*EducationSummary, error)
+	GetEducationsByPutCodes(ctx context.Context, orcidID string, putCodes []int64) ([]*EducationSummary, error)
+	GetEmployments(ctx context.Context, orcidID string) (*Employments, error)
+	GetEmployment(ctx context.Context, orcidID string, putCode string) (*EmploymentSummary, error)
+	GetEmploymentsByPutCodes(ctx context.Context, orcidID string, putCodes []int64) ([]*EmploymentSummary, error)
+	GetDistinctions(ctx context.Context, orcidID string) (*Distinctions, error)
+	GetDistinction(ctx context.Context, orcidID string, putCode string) (*DistinctionSummary, error)
+	GetInvitedPositions(ctx context.Context, orcidID string) (*InvitedPositions, error)
+	GetInvitedPosition(ctx context.Context, orcidID string, putCode string) (*InvitedPositionSummary, error)
+	GetMemberships(ctx context.Context, orcidID string) (*Memberships, error)
+	GetMembership(ctx context.Context, orcidID string, putCode string) (*MembershipSummary, error)
+	GetQualifications(ctx context.Context, orcidID string) (*Qualifications, error)
+	GetQualification(ctx context.Context, orcidID string, putCode string) (*QualificationSummary, error)
+	GetServices(ctx context.Context, orcidID string) (*Services, error)
+	GetService(ctx context.Context, orcidID string, putCode string) (*ServiceSummary, error)
+	GetFundings(ctx context.Context, orcidID string) (*Fundings, error)
+	GetFunding(ctx context.Context, orcidID string, putCode string) (*FundingSummary, error)
+	GetPeerReviews(ctx context.Context, orcidID string) (*PeerReviews, error)
+	GetPeerReview(ctx context.Context, orcidID string, putCode string) (*PeerReviewSummary, error)
+	GetResearchResources(ctx context.Context, orcidID string) (*ResearchResources, error)
+
+	// Search
+	Search(ctx context.Context, params SearchParams) (*SearchResult, error)
+	SearchWithQuery(ctx context.Context, query *SearchQuery) (*SearchResult, error)
+	Count(ctx context.Context, query *SearchQuery) (int, error)
+	BatchSearch(ctx context.Context, queries []*SearchQuery) ([]*SearchResult, []error)
+	ExpandedSearch(ctx context.Context, query string) (*ExpandedSearchResult, error)
+	ExpandedSearchWithParams(ctx context.Context, params ExpandedSearchParams) (*ExpandedSearchResult, error)
+
+	// Member API writes
+	AddWork(ctx context.Context, orcidID string, work *Work) (int64, error)
+	AddWorks(ctx context.Context, orcidID string, works []*Work) (*BulkWorkResult, error)
+	UpdateWork(ctx context.Context, orcidID string, putCode int64, work *Work) (*Work, error)
+	DeleteWork(ctx context.Context, orcidID string, putCode int64) error
+	AddEducation(ctx context.Context, orcidID string, edu *EducationSummary) (int64, error)
+	UpdateEmployment(ctx context.Context, orcidID string, putCode int64, employment *EmploymentSummary) (*EmploymentSummary, error)
+	DeleteEmployment(ctx context.Context, orcidID string, putCode int64) error
+	AddFunding(ctx context.Context, orcidID string, funding *FundingSummary) (int64, error)
+	UpdateFunding(ctx context.Context, orcidID string, putCode int64, funding *FundingSummary) (*FundingSummary, error)
+	DeleteFunding(ctx context.Context, orcidID string, putCode int64) error
+	PostByPath(ctx context.Context, path Path, body interface{}) (int64, error)
+}
+
+var _ API = (*Client)(nil)
@@ -0,0 +1,238 @@
+package orcid
+
+import (
+	"bytes"
+	"container/list"
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Cache stores the bodies of GET responses. Keys are opaque strings built by
+// the client from the request URL and content type, since JSON and XML are
+// served from the same URLs. modified is the response's Last-Modified time,
+// or the time it was stored when the server did not send one.
+// Implementations must be safe for concurrent use.
+//
+// A cache that also has a Clear method is emptied by Client.ClearCache and
+// Client.Close.
+type Cache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, data []byte, modified time.Time)
+}
+
+// WithCache enables conditional GET requests backed by cache. Responses that
+// carry an ETag or Last-Modified header are stored, and later requests for
+// the same URL send If-None-Match or If-Modified-Since so that an unchanged
+// resource is answered with HTTP 304 and served from the cache.
+func WithCache(cache Cache) ClientOption {
+	return func(c *Client) {
+		c.cache = cache
+	}
+}
+
+// ClearCache empties the response cache and forgets the validators used for
+// conditional requests. It is safe to call while requests are in flight.
+func (c *Client) ClearCache() {
+	c.cacheMu.Lock()
+	c.cacheValidators = nil
+	c.cacheMu.Unlock()
+
+	if clearer, ok := c.cache.(interface{ Clear() }); ok {
+		clearer.Clear()
+	}
+}
+
+type bypassCacheKey struct{}
+
+// BypassCache returns a context whose requests neither read from nor write
+// to the client's response cache, for when a fresh copy is required.
+func BypassCache(ctx context.Context) context.Context {
+	return context.WithValue(ctx, bypassCacheKey{}, true)
+}
+
+func cacheBypassed(ctx context.Context) bool {
+	bypass, _ := ctx.Value(bypassCacheKey{}).(bool)
+	return bypass
+}
+
+// cacheValidators are the response headers needed to revalidate a cached
+// body. They are kept by the client rather than in the Cache, which only
+// holds bodies.
+type cacheValidators struct {
+	etag         string
+	lastModified string
+}
+
+func (v cacheValidators) header() http.Header {
+	header := http.Header{}
+	if v.etag != "" {
+		header.Set("If-None-Match", v.etag)
+	}
+	if v.lastModified != "" {
+		header.Set("If-Modified-Since", v.lastModified)
+	}
+	return header
+}
+
+func (c *Client) cacheKey(contentType ContentType, url string) string {
+	return string(contentType) + " " + url
+}
+
+// cachedRequest returns the cached body for key and the headers that
+// revalidate it, or nil if there is nothing usable in the cache.
+func (c *Client) cachedRequest(key string) ([]byte, http.Header) {
+	c.cacheMu.Lock()
+	validators, ok := c.cacheValidators[key]
+	c.cacheMu.Unlock()
+	if !ok {
+		return nil, nil
+	}
+
+	data, ok := c.cache.Get(key)
+	if !ok {
+		return nil, nil
+	}
+	return data, validators.header()
+}
+
+// ifMatchHeader returns an If-Match header carrying the ETag cached for a
+// GET of url, or nil if there is none. Weak ETags cannot be used with
+// If-Match and are ignored.
+func (c *Client) ifMatchHeader(ctx context.Context, contentType ContentType, url string) http.Header {
+	if c.cache == nil || cacheBypassed(ctx) {
+		return nil
+	}
+	c.cacheMu.Lock()
+	validators := c.cacheValidators[c.cacheKey(contentType, url)]
+	c.cacheMu.Unlock()
+	if validators.etag == "" || strings.HasPrefix(validators.etag, "W/") {
+		return nil
+	}
+	header := http.Header{}
+	header.Set("If-Match", validators.etag)
+	return header
+}
+
+// forgetValidators drops the validators for a GET of url after the resource
+// was changed, so the stale cached body is neither revalidated nor used.
+func (c *Client) forgetValidators(contentType ContentType, url string) {
+	c.cacheMu.Lock()
+	delete(c.cacheValidators, c.cacheKey(contentType, url))
+	c.cacheMu.Unlock()
+}
+
+// storeResponse reads a successful response into the cache if it can be
+// revalidated later, and returns an equivalent response for the caller.
+func (c *Client) storeResponse(key string, resp *http.Response) (*http.Response, error) {
+	validators := cacheValidators{
+		etag:         resp.Header.Get("ETag"),
+		lastModified: resp.Header.Get("Last-Modified"),
+	}
+	if validators.etag == "" && validators.lastModified == "" {
+		return resp, nil
+	}
+
+	data, err := io.ReadAll(resp.Body)
+	resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	modified, err := http.ParseTime(validators.lastModified)
+	if err != nil {
+		modified = time.Now()
+	}
+	c.cache.Set(key, data, modified)
+
+	c.cacheMu.Lock()
+	if c.cacheValidators == nil {
+		c.cacheValidators = make(map[string]cacheValidators)
+	}
+	c.cacheValidators[key] = validators
+	c.cacheMu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(data))
+	return resp, nil
+}
+
+// cachedResponse turns a 304 response into a 200 carrying the cached body.
+func cachedResponse(resp *http.Response, data []byte) *http.Response {
+	resp.Body.Close()
+	cached := *resp
+	cached.StatusCode = http.StatusOK
+	cached.Status = "200 OK"
+	cached.ContentLength = int64(len(data))
+	cached.Body = io.NopCloser(bytes.NewReader(data))
+	return &cached
+}
+
+// MemoryCache is an in-memory Cache that evicts the least recently used
+// entry once it holds maxEntries entries.
+type MemoryCache struct {
+	mu         sync.Mutex
+	maxEntries int
+	order      *list.List
+	entries    map[string]*list.Element
+}
+
+type memoryCacheEntry struct {
+	key  string
+	data []byte
+}
+
+// NewMemoryCache returns an empty MemoryCache holding at most maxEntries
+// responses. A maxEntries of zero or less means no limit.
+func NewMemoryCache(maxEntries int) *MemoryCache {
+	return &MemoryCache{
+		maxEntries: maxEntries,
+		order:      list.New(),
+		entries:    make(map[string]*list.Element),
+	}
+}
+
+func (m *MemoryCache) Get(key string) ([]byte, bool) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	elem, ok := m.entries[key]
+	if !ok {
+		return nil, false
+	}
+	m.order.MoveToFront(elem)
+	return elem.Value.(*memoryCacheEntry).data, true
+}
+
+func (m *MemoryCache) Set(key string, data []byte, modified time.Time) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if elem, ok := m.entries[key]; ok {
+		elem.Value.(*memoryCacheEntry).data = data
+		m.order.MoveToFront(elem)
+		return
+	}
+
+	m.entries[key] = m.order.PushFront(&memoryCacheEntry{key: key, data: data})
+	if m.maxEntries > 0 && m.order.Len() > m.maxEntries {
+		oldest := m.order.Back()
+		m.order.Remove(oldest)
+		delete(m.entries, oldest.Value.(*memoryCacheEntry).key)
+	}
+}
+
+// Len returns the number of cached entries.
+func (m *MemoryCache) Len() int {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	return m.order.Len()
+}
+
+// Clear removes every entry.
+func (m *MemoryCache) Clear() {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.order.Init()
+	m.entries = make(map[string]*list.Element)
+}
@@ -0,0 +1,241 @@
+package orcid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestGetRecordIfModified(t *testing.T) {
+	lastModified := time.Date(2024, 3, 1, 12, 0, 0, 0, time.UTC)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		since, err := http.ParseTime(r.Header.Get("If-Modified-Since"))
+		if err != nil {
+			t.Errorf("Expected a valid If-Modified-Since header, got %q", r.Header.Get("If-Modified-Since"))
+		}
+		if !lastModified.After(since) {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Last-Modified", lastModified.Format(http.TimeFormat))
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"orcid-identifier": {"path": "0000-0002-1825-0097"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+	ctx := context.Background()
+
+	record, modified, err := client.GetRecordIfModified(ctx, "0000-0002-1825-0097", lastModified.Add(-24*time.Hour))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !modified || record == nil {
+		t.Fatalf("Expected a modified record, got %v, %v", record, modified)
+	}
+	if record.OrcidIdentifier.Path != "0000-0002-1825-0097" {
+		t.Errorf("Expected path %s, got %s", "0000-0002-1825-0097", record.OrcidIdentifier.Path)
+	}
+
+	record, modified, err = client.GetRecordIfModified(ctx, "0000-0002-1825-0097", lastModified)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if modified || record != nil {
+		t.Errorf("Expected nil, false for an unchanged record, got %v, %v", record, modified)
+	}
+}
+
+func TestCacheConditionalRequests(t *testing.T) {
+	requests := 0
+	var ifNoneMatch []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		ifNoneMatch = append(ifNoneMatch, r.Header.Get("If-None-Match"))
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"orcid-identifier": {"path": "0000-0002-1825-0097"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithCache(NewMemoryCache(0)),
+	)
+	ctx := context.Background()
+
+	for i := 0; i < 3; i++ {
+		record, err := client.GetRecord(ctx, "0000-0002-1825-0097")
+		if err != nil {
+			t.Fatalf("Unexpected error on request %d: %v", i, err)
+		}
+		if record.OrcidIdentifier == nil || record.OrcidIdentifier.Path != "0000-0002-1825-0097" {
+			t.Errorf("Expected cached record on request %d, got %+v", i, record.OrcidIdentifier)
+		}
+	}
+
+	if requests != 3 {
+		t.Errorf("Expected 3 requests, got %d", requests)
+	}
+	if ifNoneMatch[0] != "" || ifNoneMatch[1] != `"v1"` || ifNoneMatch[2] != `"v1"` {
+		t.Errorf("Expected If-None-Match on repeated requests only, got %q", ifNoneMatch)
+	}
+	stats := client.Stats()
+	if stats.CacheHits != 2 || stats.CacheMisses != 1 {
+		t.Errorf("Expected 2 cache hits and 1 miss, got %d and %d", stats.CacheHits, stats.CacheMisses)
+	}
+
+	client.ClearCache()
+	if _, err := client.GetRecord(ctx, "0000-0002-1825-0097"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := ifNoneMatch[len(ifNoneMatch)-1]; got != "" {
+		t.Errorf("Expected no If-None-Match after ClearCache, got %q", got)
+	}
+}
+
+func TestCloseClearsCache(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"orcid-identifier": {"path": "0000-0002-1825-0097"}}`))
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache(0)
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithCache(cache),
+	)
+
+	if _, err := client.GetRecord(context.Background(), "0000-0002-1825-0097"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cache.Len() != 1 {
+		t.Fatalf("Expected 1 cached entry, got %d", cache.Len())
+	}
+
+	client.Close()
+	if cache.Len() != 0 {
+		t.Errorf("Expected Close to clear the cache, got %d entries", cache.Len())
+	}
+	if len(client.cacheValidators) != 0 {
+		t.Errorf("Expected Close to drop cache validators, got %d", len(client.cacheValidators))
+	}
+}
+
+func TestMemoryCacheEviction(t *testing.T) {
+	cache := NewMemoryCache(2)
+	cache.Set("a", []byte("1"), time.Time{})
+	cache.Set("b", []byte("2"), time.Time{})
+
+	// Touching "a" makes "b" the least recently used entry
+	if _, ok := cache.Get("a"); !ok {
+		t.Fatal("Expected a to be cached")
+	}
+	cache.Set("c", []byte("3"), time.Time{})
+
+	if _, ok := cache.Get("b"); ok {
+		t.Error("Expected b to be evicted")
+	}
+	for _, key := range []string{"a", "c"} {
+		if _, ok := cache.Get(key); !ok {
+			t.Errorf("Expected %s to be cached", key)
+		}
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected 2 entries, got %d", cache.Len())
+	}
+}
+
+func TestCacheKeysOnContentType(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("ETag", `"`+r.Header.Get("Accept")+`"`)
+		if r.Header.Get("If-None-Match") == `"`+r.Header.Get("Accept")+`"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		if r.Header.Get("Accept") == string(ContentTypeXML) {
+			w.Write([]byte(`<record><orcid-identifier><path>0000-0002-1825-0097</path></orcid-identifier></record>`))
+			return
+		}
+		w.Write([]byte(`{"orcid-identifier": {"path": "0000-0002-1825-0097"}}`))
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache(0)
+	ctx := context.Background()
+	for _, contentType := range []ContentType{ContentTypeJSON, ContentTypeXML, ContentTypeJSON, ContentTypeXML} {
+		client := NewClient(
+			WithAPIURL(server.URL+"/v3.0"),
+			WithBearerToken("test-token"),
+			WithContentType(contentType),
+			WithCache(cache),
+		)
+		data, err := client.GetRecordRaw(ctx, "0000-0002-1825-0097")
+		if err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+		if contentType == ContentTypeXML && data[0] != '<' || contentType == ContentTypeJSON && data[0] != '{' {
+			t.Errorf("Expected a %s body, got %s", contentType, data)
+		}
+	}
+	if cache.Len() != 2 {
+		t.Errorf("Expected separate JSON and XML entries, got %d", cache.Len())
+	}
+}
+
+func TestBypassCache(t *testing.T) {
+	var conditional int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("If-None-Match") != "" {
+			conditional++
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"orcid-identifier": {"path": "0000-0002-1825-0097"}}`))
+	}))
+	defer server.Close()
+
+	cache := NewMemoryCache(0)
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithCache(cache),
+	)
+	ctx := context.Background()
+
+	if _, err := client.GetRecord(BypassCache(ctx), "0000-0002-1825-0097"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if cache.Len() != 0 {
+		t.Errorf("Expected a bypassed request not to be cached, got %d entries", cache.Len())
+	}
+
+	if _, err := client.GetRecord(ctx, "0000-0002-1825-0097"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.GetRecord(BypassCache(ctx), "0000-0002-1825-0097"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if conditional != 0 {
+		t.Errorf("Expected no conditional requests, got %d", conditional)
+	}
+}
@@ -1,25 +1,43 @@
 package orcid
 
 import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
 	"context"
 	"encoding/json"
 	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
+	"log/slog"
+	"math/rand"
 	"net/http"
 	"net/url"
+	"slices"
+	"strings"
+	"sync"
 	"time"
 )
 
+// Base URLs of the ORCID APIs. The API version is appended by the client,
+// see WithAPIVersion.
 const (
-	MemberSandboxHost = "https://api.sandbox.orcid.org/v3.0"
-	PublicSandboxHost = "https://pub.sandbox.orcid.org/v3.0"
-	MemberHost        = "https://api.orcid.org/v3.0"
-	PublicHost        = "https://pub.orcid.org/v3.0"
+	MemberSandboxHost = "https://api.sandbox.orcid.org"
+	PublicSandboxHost = "https://pub.sandbox.orcid.org"
+	MemberHost        = "https://api.orcid.org"
+	PublicHost        = "https://pub.orcid.org"
+)
+
+// Base URLs of the ORCID websites, used for profile links and OAuth.
+const (
+	SiteURL        = "https://orcid.org"
+	SandboxSiteURL = "https://sandbox.orcid.org"
 )
 
 const (
 	DefaultAPIURL     = PublicHost
+	DefaultAPIVersion = "v3.0"
 	DefaultTimeout    = 30 * time.Second
 	DefaultMaxRetries = 3
 	DefaultRateLimit  = 10
@@ -30,30 +48,76 @@ type ContentType string
 const (
 	ContentTypeJSON ContentType = "application/json"
 	ContentTypeXML  ContentType = "application/vnd.orcid+xml"
+	// ContentTypeORCIDJSON is ORCID's vendor JSON type, which some endpoints
+	// answer differently from plain JSON. Responses are decoded as JSON.
+	ContentTypeORCIDJSON ContentType = "application/vnd.orcid+json"
 )
 
 type Client struct {
 	httpClient  *http.Client
 	apiURL      string
+	apiURLSet   bool
+	apiType     APIType
+	sandbox     bool
+	apiVersion  string
+	versionErr  error
 	timeout     time.Duration
 	maxRetries  int
+	backoff     func(attempt int) time.Duration
 	rateLimit   int
 	userAgent   string
+	headers     http.Header
 	contentType ContentType
+	compression bool
 	rateLimiter *time.Ticker
 	bearerToken string
+	stats       clientStats
+
+	resourceLimiter   *keyedLimiter
+	serverRateLimit   serverRateLimit
+	throttleThreshold int
+
+	closeOnce sync.Once
+	done      chan struct{}
+
+	tokenMu           sync.Mutex
+	refreshableToken  *Token
+	refreshRetryAt    time.Time
+	oauthClientID     string
+	oauthClientSecret string
+	onTokenRefresh    func(*Token)
+
+	requestHook  func(RequestInfo)
+	responseHook func(ResponseInfo)
+	logger       *slog.Logger
+
+	publicWriteOnce sync.Once
+
+	crossRefURL string
+
+	cache           Cache
+	cacheMu         sync.Mutex
+	cacheValidators map[string]cacheValidators
 }
 
 type ClientOption func(*Client)
 
+var errClientClosed = fmt.Errorf("client is closed")
+
+// NewClient returns a client configured by opts. A Client is safe for
+// concurrent use and is meant to be built once and reused; when it is no
+// longer needed, call Close to stop its rate limiter.
 func NewClient(opts ...ClientOption) *Client {
 	c := &Client{
-		httpClient:  &http.Client{Timeout: DefaultTimeout},
+		httpClient:  &http.Client{Timeout: DefaultTimeout, CheckRedirect: checkRedirect},
 		apiURL:      DefaultAPIURL,
 		timeout:     DefaultTimeout,
 		maxRetries:  DefaultMaxRetries,
+		backoff:     defaultBackoff,
 		rateLimit:   DefaultRateLimit,
 		contentType: ContentTypeJSON,
+		compression: true,
+		done:        make(chan struct{}),
 	}
 
 	for _, opt := range opts {
@@ -64,17 +128,52 @@ func NewClient(opts ...ClientOption) *Client {
 		c.rateLimiter = time.NewTicker(time.Second / time.Duration(c.rateLimit))
 	}
 
+	if !c.apiURLSet && (c.apiType != 0 || c.sandbox) {
+		c.apiURL = apiHost(c.apiType, c.sandbox)
+	}
+	c.apiURL, c.versionErr = resolveAPIURL(c.apiURL, c.apiVersion)
+
 	return c
 }
 
+// NewClientWithError is like NewClient but validates the resulting
+// configuration, so that a bad option fails at construction instead of on
+// every request.
+func NewClientWithError(opts ...ClientOption) (*Client, error) {
+	c := NewClient(opts...)
+	if err := c.validate(); err != nil {
+		c.Close()
+		return nil, err
+	}
+	return c, nil
+}
+
+func (c *Client) validate() error {
+	if c.versionErr != nil {
+		return c.versionErr
+	}
+	switch c.contentType {
+	case ContentTypeJSON, ContentTypeORCIDJSON, ContentTypeXML:
+	default:
+		return fmt.Errorf("unsupported content type: %s", c.contentType)
+	}
+	return nil
+}
+
+// WithHTTPClient replaces the underlying HTTP client. The supplied client's
+// redirect policy is used as-is, so it will not get the ORCID-aware
+// checkRedirect behaviour of the default client.
 func WithHTTPClient(client *http.Client) ClientOption {
 	return func(c *Client) {
 		c.httpClient = client
 	}
 }
 
+// WithAPIURL sets the API URL explicitly. It takes precedence over the host
+// selected by WithAPIType and WithSandbox.
 func WithAPIURL(url string) ClientOption {
 	return func(c *Client) {
+		c.apiURLSet = true
 		// Remove trailing slash if present
 		if len(url) > 0 && url[len(url)-1] == '/' {
 			c.apiURL = url[:len(url)-1]
@@ -84,6 +183,131 @@ func WithAPIURL(url string) ClientOption {
 	}
 }
 
+// APIType distinguishes ORCID's public API, which is read-only, from the
+// member API, which also accepts writes and limited-visibility reads.
+type APIType int
+
+const (
+	APIPublic APIType = iota + 1
+	APIMember
+)
+
+// WithAPIType selects the public or member API host, so callers need not
+// pick between PublicHost and MemberHost themselves. Combine it with
+// WithSandbox to target the sandbox.
+func WithAPIType(apiType APIType) ClientOption {
+	return func(c *Client) {
+		c.apiType = apiType
+	}
+}
+
+// WithSandbox selects the sandbox host of the API chosen by WithAPIType, or
+// of the public API if no type is given.
+func WithSandbox(sandbox bool) ClientOption {
+	return func(c *Client) {
+		c.sandbox = sandbox
+	}
+}
+
+// apiHost returns the base URL for the given API type and environment.
+func apiHost(apiType APIType, sandbox bool) string {
+	switch {
+	case apiType == APIMember && sandbox:
+		return MemberSandboxHost
+	case apiType == APIMember:
+		return MemberHost
+	case sandbox:
+		return PublicSandboxHost
+	default:
+		return PublicHost
+	}
+}
+
+// publicAPI reports whether the client talks to the read-only public API,
+// going by WithAPIType or, failing that, the host of the API URL.
+func (c *Client) publicAPI() bool {
+	if c.apiType != 0 {
+		return c.apiType == APIPublic
+	}
+	u, err := url.Parse(c.apiURL)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(u.Hostname(), "pub.") && isORCIDHost(u.Hostname())
+}
+
+// warnPublicWrite logs, once per client, that a write is being sent to the
+// public API, which will reject it.
+func (c *Client) warnPublicWrite(ctx context.Context, method, url string) {
+	if !c.publicAPI() {
+		return
+	}
+	c.publicWriteOnce.Do(func() {
+		logger := c.logger
+		if logger == nil {
+			logger = slog.Default()
+		}
+		logger.WarnContext(ctx, "ORCID write request sent to the public API, which only supports reads; use WithAPIType(APIMember)",
+			"method", method,
+			"endpoint", c.endpoint(url))
+	})
+}
+
+// SupportedAPIVersions are the ORCID API versions WithAPIVersion accepts.
+// The models in this package follow v3.0; v2.x responses decode on a best
+// effort basis.
+var SupportedAPIVersions = []string{"v2.0", "v2.1", "v3.0"}
+
+// WithAPIVersion selects the ORCID API version, replacing the version path
+// segment of the API URL or appending it when the URL has none. Without this
+// option a URL lacking a version gets DefaultAPIVersion. An unsupported
+// version makes NewClientWithError fail and every request of a client from
+// NewClient return an error.
+func WithAPIVersion(version string) ClientOption {
+	return func(c *Client) {
+		c.apiVersion = version
+	}
+}
+
+// resolveAPIURL applies version to apiURL as described for WithAPIVersion.
+func resolveAPIURL(apiURL, version string) (string, error) {
+	if version != "" && !slices.Contains(SupportedAPIVersions, version) {
+		return apiURL, fmt.Errorf("unsupported API version %q, expected one of %s",
+			version, strings.Join(SupportedAPIVersions, ", "))
+	}
+
+	base := apiURL
+	if i := strings.LastIndex(apiURL, "/"); i >= 0 && isAPIVersion(apiURL[i+1:]) {
+		if version == "" {
+			return apiURL, nil
+		}
+		base = apiURL[:i]
+	}
+	if version == "" {
+		version = DefaultAPIVersion
+	}
+	return base + "/" + version, nil
+}
+
+// isAPIVersion reports whether segment looks like an API version path
+// segment, such as "v3.0" or "v3.0_rc1".
+func isAPIVersion(segment string) bool {
+	if len(segment) < 4 || segment[0] != 'v' {
+		return false
+	}
+	number, _, _ := strings.Cut(segment[1:], "_")
+	major, minor, ok := strings.Cut(number, ".")
+	if !ok || major == "" || minor == "" {
+		return false
+	}
+	for _, r := range major + minor {
+		if r < '0' || r > '9' {
+			return false
+		}
+	}
+	return true
+}
+
 func WithTimeout(timeout time.Duration) ClientOption {
 	return func(c *Client) {
 		c.timeout = timeout
@@ -97,111 +321,529 @@ func WithMaxRetries(maxRetries int) ClientOption {
 	}
 }
 
+// WithBackoff sets how long to wait before each retry. backoff is called
+// with the retry number, starting at 1. The default waits attempt² seconds.
+func WithBackoff(backoff func(attempt int) time.Duration) ClientOption {
+	return func(c *Client) {
+		if backoff != nil {
+			c.backoff = backoff
+		} else {
+			c.backoff = defaultBackoff
+		}
+	}
+}
+
+func defaultBackoff(attempt int) time.Duration {
+	return time.Duration(attempt*attempt) * time.Second
+}
+
+// ExponentialJitterBackoff returns a backoff for WithBackoff that doubles
+// from base on every retry, up to max, and waits a random duration between
+// half of that and all of it, so that clients retrying together spread out.
+func ExponentialJitterBackoff(base, max time.Duration) func(attempt int) time.Duration {
+	return func(attempt int) time.Duration {
+		d := max
+		if attempt < 1 {
+			attempt = 1
+		}
+		if attempt <= 62 && base <= max>>(attempt-1) {
+			d = base << (attempt - 1)
+		}
+		if d <= 0 {
+			return 0
+		}
+		half := d / 2
+		return half + time.Duration(rand.Int63n(int64(d-half)+1))
+	}
+}
+
+// WithRateLimit sets the global request rate. A value of zero or less
+// disables the limiter. The ticker itself is created once by NewClient, so
+// the option can be applied repeatedly without leaking timers.
 func WithRateLimit(requestsPerSecond int) ClientOption {
 	return func(c *Client) {
 		c.rateLimit = requestsPerSecond
+	}
+}
+
+// WithPerResourceRateLimit limits requests for any single ORCID iD to the
+// given rate, in addition to the global rate limit. Requests for different
+// iDs do not wait on each other.
+func WithPerResourceRateLimit(requestsPerSecond int) ClientOption {
+	return func(c *Client) {
 		if requestsPerSecond > 0 {
-			c.rateLimiter = time.NewTicker(time.Second / time.Duration(requestsPerSecond))
+			c.resourceLimiter = newKeyedLimiter(requestsPerSecond)
+		} else {
+			c.resourceLimiter = nil
 		}
 	}
 }
 
+// WithProactiveThrottle makes the client pause until the rate limit window
+// resets once the X-RateLimit-Remaining header of a response drops below
+// threshold, instead of waiting for the server to answer with HTTP 429.
+func WithProactiveThrottle(threshold int) ClientOption {
+	return func(c *Client) {
+		c.throttleThreshold = threshold
+	}
+}
+
 func WithUserAgent(userAgent string) ClientOption {
 	return func(c *Client) {
 		c.userAgent = userAgent
 	}
 }
 
+// WithHeader adds a header sent with every request, such as X-Request-ID
+// for tracing or a header required by an institutional proxy. It may be
+// given several times, and values for the same key accumulate. Headers the
+// client sets itself, including Authorization, Accept and User-Agent, take
+// precedence.
+func WithHeader(key, value string) ClientOption {
+	return func(c *Client) {
+		if c.headers == nil {
+			c.headers = http.Header{}
+		}
+		c.headers.Add(key, value)
+	}
+}
+
+// WithHeaders adds every header in header, as WithHeader does.
+func WithHeaders(header http.Header) ClientOption {
+	return func(c *Client) {
+		for key, values := range header {
+			for _, value := range values {
+				WithHeader(key, value)(c)
+			}
+		}
+	}
+}
+
 func WithContentType(contentType ContentType) ClientOption {
 	return func(c *Client) {
 		c.contentType = contentType
 	}
 }
 
+// WithCompression controls whether responses are requested gzip-compressed.
+// It is on by default; turn it off when a proxy mangles encoded responses.
+func WithCompression(enabled bool) ClientOption {
+	return func(c *Client) {
+		c.compression = enabled
+	}
+}
+
 func WithBearerToken(token string) ClientOption {
 	return func(c *Client) {
 		c.bearerToken = token
 	}
 }
 
-func (c *Client) doRequest(ctx context.Context, method, url string, body io.Reader) (*http.Response, error) {
+// Close releases the resources held by the client, stopping the rate limiter
+// and discarding per-resource limiter state and cached responses. Requests
+// made after Close, or waiting on the rate limiter when it is called, fail.
+// Close is safe to call more than once.
+func (c *Client) Close() error {
+	c.closeOnce.Do(func() {
+		close(c.done)
+		if c.rateLimiter != nil {
+			c.rateLimiter.Stop()
+		}
+		if c.resourceLimiter != nil {
+			c.resourceLimiter.reset()
+		}
+		if c.cache != nil {
+			c.ClearCache()
+		}
+	})
+	return nil
+}
+
+// doRequest sends the request, retrying transient failures. The body, if any,
+// is resent in full on every attempt.
+func (c *Client) doRequest(ctx context.Context, method, url string, body []byte) (*http.Response, error) {
+	return c.doRequestHeader(ctx, method, url, body, nil)
+}
+
+// doRequestHeader is doRequest with extra request headers. Requests that set
+// their own headers bypass the response cache, and an HTTP 304 answering
+// their conditional headers is returned to the caller as is.
+func (c *Client) doRequestHeader(ctx context.Context, method, url string, body []byte, header http.Header) (*http.Response, error) {
+	if c.versionErr != nil {
+		return nil, c.versionErr
+	}
+	if method != http.MethodGet {
+		c.warnPublicWrite(ctx, method, url)
+	}
+
+	contentType, err := c.contentTypeFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	bearerToken := requestOptionsFrom(ctx).bearerToken
+	if bearerToken == "" {
+		bearerToken, err = c.accessToken(ctx)
+		if err != nil {
+			return nil, err
+		}
+	}
 	// ORCID API requires bearer token authentication for all requests
-	if c.bearerToken == "" {
+	if bearerToken == "" {
 		return nil, fmt.Errorf("bearer token is required for ORCID API requests. Use WithBearerToken() when creating the client")
 	}
 
+	select {
+	case <-c.done:
+		return nil, errClientClosed
+	default:
+	}
+
+	if c.resourceLimiter != nil {
+		if orcidID := orcidIDFromPath(url); orcidID != "" {
+			waited, err := c.resourceLimiter.wait(ctx, orcidID)
+			if waited {
+				c.stats.rateLimitWaits.Add(1)
+			}
+			if err != nil {
+				return nil, err
+			}
+		}
+	}
+
 	if c.rateLimiter != nil {
 		select {
 		case <-c.rateLimiter.C:
-		case <-ctx.Done():
-			return nil, ctx.Err()
+		default:
+			c.stats.rateLimitWaits.Add(1)
+			select {
+			case <-c.rateLimiter.C:
+			case <-c.done:
+				return nil, errClientClosed
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
 		}
 	}
 
+	if c.throttleThreshold > 0 {
+		if delay := c.serverRateLimit.throttleDelay(c.throttleThreshold); delay > 0 {
+			c.stats.rateLimitWaits.Add(1)
+			timer := time.NewTimer(delay)
+			select {
+			case <-timer.C:
+			case <-c.done:
+				timer.Stop()
+				return nil, errClientClosed
+			case <-ctx.Done():
+				timer.Stop()
+				return nil, ctx.Err()
+			}
+		}
+	}
+
+	var cacheKey string
+	var cached []byte
+	if c.cache != nil && method == http.MethodGet && header == nil && !cacheBypassed(ctx) {
+		cacheKey = c.cacheKey(contentType, url)
+		cached, header = c.cachedRequest(cacheKey)
+		if cached == nil {
+			c.stats.cacheMisses.Add(1)
+		}
+	}
+
+	httpClient := c.httpClientFor(ctx)
 	var lastErr error
 	for attempt := 0; attempt <= c.maxRetries; attempt++ {
 		if attempt > 0 {
-			backoff := time.Duration(attempt*attempt) * time.Second
+			c.stats.retries.Add(1)
+			delay := c.backoff(attempt)
+			if c.logger != nil {
+				c.logger.WarnContext(ctx, "retrying ORCID request",
+					"orcid", orcidIDFromPath(url),
+					"endpoint", c.endpoint(url),
+					"retry", attempt,
+					"backoff", delay,
+					"error", lastErr)
+			}
 			select {
-			case <-time.After(backoff):
+			case <-time.After(delay):
 			case <-ctx.Done():
 				return nil, ctx.Err()
 			}
 		}
 
-		req, err := http.NewRequestWithContext(ctx, method, url, body)
+		var bodyReader io.Reader
+		if body != nil {
+			bodyReader = bytes.NewReader(body)
+		}
+
+		req, err := http.NewRequestWithContext(ctx, method, url, bodyReader)
 		if err != nil {
 			return nil, err
 		}
 
+		for key, values := range c.headers {
+			req.Header[key] = slices.Clone(values)
+		}
 		req.Header.Set("User-Agent", c.userAgent)
-		req.Header.Set("Accept", string(c.contentType))
-		req.Header.Set("Authorization", "Bearer "+c.bearerToken)
+		req.Header.Set("Accept", string(contentType))
+		req.Header.Set("Authorization", "Bearer "+bearerToken)
+		if body != nil {
+			req.Header.Set("Content-Type", string(contentType))
+		}
+		// Setting Accept-Encoding explicitly stops the transport from
+		// negotiating and decoding gzip on its own, so it works the same
+		// with any supplied http.Client
+		if c.compression {
+			req.Header.Set("Accept-Encoding", "gzip")
+		} else {
+			req.Header.Set("Accept-Encoding", "identity")
+		}
+		for key, values := range header {
+			req.Header[key] = values
+		}
 
-		resp, err := c.httpClient.Do(req)
+		c.stats.requests.Add(1)
+		if c.requestHook != nil {
+			c.requestHook(RequestInfo{Method: method, URL: url, Attempt: attempt + 1})
+		}
+		start := time.Now()
+		resp, err := httpClient.Do(req)
+		if c.responseHook != nil {
+			info := ResponseInfo{Method: method, URL: url, Duration: time.Since(start), Attempt: attempt + 1, Err: err}
+			if resp != nil {
+				info.StatusCode = resp.StatusCode
+				if resp.Request != nil {
+					info.FinalURL = resp.Request.URL.String()
+				}
+			}
+			c.responseHook(info)
+		}
+		if c.logger != nil {
+			attrs := []any{
+				"method", method,
+				"orcid", orcidIDFromPath(url),
+				"endpoint", c.endpoint(url),
+				"retry", attempt,
+				"duration", time.Since(start),
+			}
+			if err != nil {
+				attrs = append(attrs, "error", err)
+			} else {
+				attrs = append(attrs, "status", resp.StatusCode)
+			}
+			c.logger.DebugContext(ctx, "ORCID request", attrs...)
+		}
 		if err != nil {
+			c.stats.networkErrors.Add(1)
+			lastErr = err
+			// A POST may have been processed before the failure, so resending
+			// it could create a duplicate item
+			if method == http.MethodPost {
+				return nil, err
+			}
+			continue
+		}
+		if err := decompressResponse(resp); err != nil {
+			c.stats.networkErrors.Add(1)
 			lastErr = err
+			if method == http.MethodPost {
+				return nil, err
+			}
 			continue
 		}
+		c.stats.recordStatus(resp.StatusCode)
+		c.serverRateLimit.update(resp.Header)
 
-		if resp.StatusCode == http.StatusOK {
+		if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+			if cacheKey != "" {
+				return c.storeResponse(cacheKey, resp)
+			}
 			return resp, nil
 		}
 
+		if resp.StatusCode == http.StatusNotModified {
+			if cached != nil {
+				c.stats.cacheHits.Add(1)
+				return cachedResponse(resp, cached), nil
+			}
+			if header != nil {
+				return resp, nil
+			}
+		}
+
+		// checkRedirect stops at redirects to a different ORCID iD so the
+		// caller learns that the requested iD is not the one being served,
+		// which is how ORCID reports deprecated records
+		if resp.StatusCode >= 300 && resp.StatusCode < 400 {
+			bodyBytes, _ := io.ReadAll(resp.Body)
+			resp.Body.Close()
+			return nil, newRedirectError(resp, url, bodyBytes)
+		}
+
 		if resp.StatusCode == http.StatusTooManyRequests ||
-			resp.StatusCode == http.StatusRequestTimeout ||
-			(resp.StatusCode >= 500 && resp.StatusCode < 600) {
+			(method != http.MethodPost && (resp.StatusCode == http.StatusRequestTimeout ||
+				(resp.StatusCode >= 500 && resp.StatusCode < 600))) {
+			bodyBytes, _ := io.ReadAll(resp.Body)
 			resp.Body.Close()
-			lastErr = fmt.Errorf("HTTP %d: %s", resp.StatusCode, resp.Status)
+			lastErr = newAPIError(resp, bodyBytes)
 			continue
 		}
 
 		bodyBytes, _ := io.ReadAll(resp.Body)
 		resp.Body.Close()
-		return nil, fmt.Errorf("HTTP %d: %s - %s", resp.StatusCode, resp.Status, string(bodyBytes))
+		return nil, newAPIError(resp, bodyBytes)
 	}
 
 	return nil, fmt.Errorf("max retries exceeded: %w", lastErr)
 }
 
-func (c *Client) unmarshalResponse(data []byte, v interface{}) error {
-	switch c.contentType {
-	case ContentTypeJSON:
-		return json.Unmarshal(data, v)
+// decompressResponse replaces a gzip-encoded response body with one that
+// yields the decoded content.
+func decompressResponse(resp *http.Response) error {
+	if !strings.EqualFold(resp.Header.Get("Content-Encoding"), "gzip") {
+		return nil
+	}
+
+	zr, err := gzip.NewReader(resp.Body)
+	if err == io.EOF {
+		// An empty body, as sent with HTTP 204 or 304
+		resp.Body.Close()
+		resp.Body = http.NoBody
+		resp.Header.Del("Content-Encoding")
+		return nil
+	}
+	if err != nil {
+		resp.Body.Close()
+		return fmt.Errorf("decoding gzip response: %w", err)
+	}
+	resp.Body = &gzipBody{Reader: zr, body: resp.Body}
+	resp.Header.Del("Content-Encoding")
+	resp.Header.Del("Content-Length")
+	resp.ContentLength = -1
+	resp.Uncompressed = true
+	return nil
+}
+
+type gzipBody struct {
+	*gzip.Reader
+	body io.ReadCloser
+}
+
+func (b *gzipBody) Close() error {
+	b.Reader.Close()
+	return b.body.Close()
+}
+
+// checkRedirect keeps the Authorization header when ORCID redirects between
+// orcid.org hosts, which Go would otherwise drop on a cross-host redirect.
+// Redirects that point at a different ORCID iD are not followed. The URL a
+// followed redirect ends at is reported as ResponseInfo.FinalURL.
+func checkRedirect(req *http.Request, via []*http.Request) error {
+	if len(via) >= 10 {
+		return fmt.Errorf("stopped after %d redirects", len(via))
+	}
+
+	original := via[0]
+	if fromID, toID := orcidIDFromPath(original.URL.Path), orcidIDFromPath(req.URL.Path); fromID != "" && toID != "" && fromID != toID {
+		return http.ErrUseLastResponse
+	}
+
+	if auth := original.Header.Get("Authorization"); auth != "" && req.Header.Get("Authorization") == "" {
+		if req.URL.Host == original.URL.Host || isORCIDHost(req.URL.Hostname()) {
+			req.Header.Set("Authorization", auth)
+		}
+	}
+
+	return nil
+}
+
+func isORCIDHost(host string) bool {
+	host = strings.ToLower(host)
+	return host == "orcid.org" || strings.HasSuffix(host, ".orcid.org")
+}
+
+// orcidIDFromPath returns the formatted ORCID iD contained in an API URL or
+// URL path, or an empty string if there is none.
+func orcidIDFromPath(path string) string {
+	for _, segment := range strings.Split(path, "/") {
+		if segment != "" && ValidateOrcidID(segment) == nil {
+			return FormatOrcidID(segment)
+		}
+	}
+	return ""
+}
+
+// marshalRequest encodes a request body in the content type used for
+// requests made with ctx.
+func (c *Client) marshalRequest(ctx context.Context, v interface{}) ([]byte, error) {
+	contentType, err := c.contentTypeFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+	switch contentType {
+	case ContentTypeJSON, ContentTypeORCIDJSON:
+		return json.Marshal(v)
+	case ContentTypeXML:
+		data, err := MarshalORCIDXML(v)
+		if errors.Is(err, errNoORCIDXML) {
+			return xml.Marshal(v)
+		}
+		return data, err
+	default:
+		return nil, fmt.Errorf("unsupported content type: %s", contentType)
+	}
+}
+
+// decodeResponse decodes a response body as it is read, so that large
+// records are not held in memory twice. The format is recognised from the
+// body itself, so responses requested with RequestContentType decode too.
+func (c *Client) decodeResponse(r io.Reader, v interface{}) error {
+	br := bufio.NewReader(r)
+	switch sniffContentType(br, c.contentType) {
+	case ContentTypeJSON, ContentTypeORCIDJSON:
+		return json.NewDecoder(br).Decode(v)
 	case ContentTypeXML:
-		return xml.Unmarshal(data, v)
+		return xml.NewDecoder(br).Decode(v)
 	default:
 		return fmt.Errorf("unsupported content type: %s", c.contentType)
 	}
 }
 
+// sniffContentType skips leading whitespace in br and reports whether the
+// body is XML or JSON, or fallback if it cannot tell.
+func sniffContentType(br *bufio.Reader, fallback ContentType) ContentType {
+	for {
+		b, err := br.ReadByte()
+		if err != nil {
+			return fallback
+		}
+		switch b {
+		case ' ', '\t', '\r', '\n':
+			continue
+		case '<':
+			br.UnreadByte()
+			return ContentTypeXML
+		case '{', '[':
+			br.UnreadByte()
+			return ContentTypeJSON
+		default:
+			br.UnreadByte()
+			return fallback
+		}
+	}
+}
+
 func (c *Client) buildSearchURL(params SearchParams) string {
 	baseURL := c.apiURL + "/search"
 
 	queryParams := url.Values{}
 	queryParams.Set("q", params.Query)
 
-	if params.Start > 0 {
+	if params.Cursor != "" {
+		queryParams.Set("cursor", params.Cursor)
+	} else if params.Start > 0 {
 		queryParams.Set("start", fmt.Sprintf("%d", params.Start))
 	}
 
@@ -211,5 +853,9 @@ func (c *Client) buildSearchURL(params SearchParams) string {
 		queryParams.Set("rows", "10")
 	}
 
+	if params.Sort != "" {
+		queryParams.Set("sort", params.Sort)
+	}
+
 	return fmt.Sprintf("%s?%s", baseURL, queryParams.Encode())
 }
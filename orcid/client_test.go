@@ -1,10 +1,18 @@
 package orcid
 
 import (
+	"bytes"
+	"compress/gzip"
 	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 )
@@ -14,8 +22,8 @@ func TestNewClient(t *testing.T) {
 	if client == nil {
 		t.Fatal("Expected non-nil client")
 	}
-	if client.apiURL != DefaultAPIURL {
-		t.Errorf("Expected apiURL %s, got %s", DefaultAPIURL, client.apiURL)
+	if want := DefaultAPIURL + "/" + DefaultAPIVersion; client.apiURL != want {
+		t.Errorf("Expected apiURL %s, got %s", want, client.apiURL)
 	}
 	if client.timeout != DefaultTimeout {
 		t.Errorf("Expected timeout %v, got %v", DefaultTimeout, client.timeout)
@@ -69,6 +77,181 @@ func TestClientOptions(t *testing.T) {
 	}
 }
 
+func TestWithAPIVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []ClientOption
+		wantURL string
+	}{
+		{"default", nil, "https://pub.orcid.org/v3.0"},
+		{"host constant", []ClientOption{WithAPIURL(MemberSandboxHost)}, "https://api.sandbox.orcid.org/v3.0"},
+		{"older version", []ClientOption{WithAPIURL(MemberHost), WithAPIVersion("v2.1")}, "https://api.orcid.org/v2.1"},
+		{"replaces version", []ClientOption{WithAPIURL("https://proxy.example.org/orcid/v3.0"), WithAPIVersion("v2.0")}, "https://proxy.example.org/orcid/v2.0"},
+		{"keeps URL version", []ClientOption{WithAPIURL("https://proxy.example.org/orcid/v3.0_rc1")}, "https://proxy.example.org/orcid/v3.0_rc1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client, err := NewClientWithError(tt.opts...)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if client.apiURL != tt.wantURL {
+				t.Errorf("Expected apiURL %s, got %s", tt.wantURL, client.apiURL)
+			}
+		})
+	}
+}
+
+func TestWithAPIVersionUnsupported(t *testing.T) {
+	if _, err := NewClientWithError(WithAPIVersion("3.0")); err == nil {
+		t.Error("Expected error for unsupported API version")
+	}
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected no request to be sent")
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIURL(server.URL), WithAPIVersion("v3.1"))
+	defer client.Close()
+	if _, err := client.GetRecord(context.Background(), "0000-0002-1825-0097"); err == nil {
+		t.Error("Expected error for unsupported API version")
+	}
+}
+
+func TestWithAPIType(t *testing.T) {
+	tests := []struct {
+		name    string
+		opts    []ClientOption
+		wantURL string
+	}{
+		{"member", []ClientOption{WithAPIType(APIMember)}, MemberHost + "/v3.0"},
+		{"member sandbox", []ClientOption{WithAPIType(APIMember), WithSandbox(true)}, MemberSandboxHost + "/v3.0"},
+		{"public sandbox", []ClientOption{WithSandbox(true)}, PublicSandboxHost + "/v3.0"},
+		{"explicit URL wins", []ClientOption{WithAPIURL("https://proxy.example.org/v3.0"), WithAPIType(APIMember)}, "https://proxy.example.org/v3.0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := NewClient(tt.opts...)
+			defer client.Close()
+			if client.apiURL != tt.wantURL {
+				t.Errorf("Expected apiURL %s, got %s", tt.wantURL, client.apiURL)
+			}
+		})
+	}
+}
+
+func TestPublicAPIWriteWarning(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewTextHandler(&buf, &slog.HandlerOptions{Level: slog.LevelWarn}))
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithAPIType(APIPublic),
+		WithBearerToken("test-token"),
+		WithLogger(logger),
+	)
+	defer client.Close()
+
+	for i := 0; i < 2; i++ {
+		if err := client.DeleteWork(context.Background(), "0000-0002-1825-0097", 123); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	if got := strings.Count(buf.String(), "public API"); got != 1 {
+		t.Errorf("Expected 1 public API warning, got %d: %s", got, buf.String())
+	}
+
+	buf.Reset()
+	member := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithAPIType(APIMember),
+		WithBearerToken("test-token"),
+		WithLogger(logger),
+	)
+	defer member.Close()
+	if err := member.DeleteWork(context.Background(), "0000-0002-1825-0097", 123); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if buf.Len() != 0 {
+		t.Errorf("Expected no warning for the member API, got %s", buf.String())
+	}
+}
+
+func TestWithHeaders(t *testing.T) {
+	var got http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Clone()
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithHeader("X-Request-ID", "abc123"),
+		WithHeaders(http.Header{
+			"X-Proxy-Group": {"library"},
+			"Authorization": {"Bearer other-token"},
+		}),
+	)
+	defer client.Close()
+
+	if _, err := client.GetRecord(context.Background(), "0000-0002-1825-0097"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if v := got.Get("X-Request-ID"); v != "abc123" {
+		t.Errorf("Expected X-Request-ID %q, got %q", "abc123", v)
+	}
+	if v := got.Get("X-Proxy-Group"); v != "library" {
+		t.Errorf("Expected X-Proxy-Group %q, got %q", "library", v)
+	}
+	if v := got.Values("Authorization"); len(v) != 1 || v[0] != "Bearer test-token" {
+		t.Errorf("Expected Authorization to stay %q, got %q", "Bearer test-token", v)
+	}
+}
+
+func TestORCIDJSONContentType(t *testing.T) {
+	var accept string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		accept = r.Header.Get("Accept")
+		w.Header().Set("Content-Type", string(ContentTypeORCIDJSON))
+		w.Write([]byte(`{"orcid-identifier": {"path": "0000-0002-1825-0097"}}`))
+	}))
+	defer server.Close()
+
+	client, err := NewClientWithError(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithContentType(ContentTypeORCIDJSON),
+	)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	defer client.Close()
+
+	record, err := client.GetRecord(context.Background(), "0000-0002-1825-0097")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if accept != string(ContentTypeORCIDJSON) {
+		t.Errorf("Expected Accept %s, got %s", ContentTypeORCIDJSON, accept)
+	}
+	if record.OrcidIdentifier == nil || record.OrcidIdentifier.Path != "0000-0002-1825-0097" {
+		t.Errorf("Expected decoded record, got %+v", record.OrcidIdentifier)
+	}
+
+	var decoded Record
+	if err := client.decodeResponse(strings.NewReader(`{"orcid-identifier": {"path": "0000-0002-1825-0097"}}`), &decoded); err != nil {
+		t.Errorf("Unexpected error from decodeResponse: %v", err)
+	}
+}
+
 func TestMissingBearerToken(t *testing.T) {
 	client := NewClient()
 	ctx := context.Background()
@@ -342,6 +525,86 @@ func TestSearchWithQuery(t *testing.T) {
 	}
 }
 
+func TestSearchSort(t *testing.T) {
+	var sorts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		sorts = append(sorts, r.URL.Query().Get("sort"))
+		if strings.Contains(r.URL.RawQuery, "sort=") && !strings.Contains(r.URL.RawQuery, "sort=profile-submission-date+desc") {
+			t.Errorf("Expected sort to be form encoded, got %s", r.URL.RawQuery)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"num-found": 0, "result": []}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(100),
+	)
+	ctx := context.Background()
+
+	queries := []*SearchQuery{
+		NewSearchQuery().FamilyName("Einstein"),
+		NewSearchQuery().FamilyName("Einstein").SortBy("profile-submission-date", false),
+		NewSearchQuery().FamilyName("Einstein").SortBy("profile-submission-date", false).SortBy("orcid", true),
+	}
+	for _, query := range queries {
+		if _, err := client.SearchWithQuery(ctx, query); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	expected := []string{"", "profile-submission-date desc", "profile-submission-date desc,orcid asc"}
+	for i, want := range expected {
+		if sorts[i] != want {
+			t.Errorf("Expected sort %q for query %d, got %q", want, i, sorts[i])
+		}
+	}
+}
+
+func TestCount(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		if query.Get("rows") != "0" {
+			t.Errorf("Expected rows %s, got %s", "0", query.Get("rows"))
+		}
+		if query.Get("q") != "family-name:Einstein" {
+			t.Errorf("Expected query %s, got %s", "family-name:Einstein", query.Get("q"))
+		}
+		if query.Has("start") || query.Has("sort") {
+			t.Errorf("Expected no start or sort for a count, got %s", r.URL.RawQuery)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"num-found": 1234, "result": null}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+
+	count, err := client.Count(context.Background(), NewSearchQuery().
+		FamilyName("Einstein").
+		WithStart(50).
+		WithRows(100).
+		SortBy("orcid", true))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if count != 1234 {
+		t.Errorf("Expected count %d, got %d", 1234, count)
+	}
+
+	if _, err := client.Count(context.Background(), NewSearchQuery().FamilyName("Einstein").And()); err == nil {
+		t.Error("Expected error for an invalid query")
+	}
+}
+
 func TestRetryLogic(t *testing.T) {
 	attempts := 0
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -450,6 +713,93 @@ func TestValidateOrcidID(t *testing.T) {
 	}
 }
 
+func TestValidateOrcidIDReason(t *testing.T) {
+	tests := []struct {
+		orcidID string
+		reason  OrcidValidationReason
+		message string
+	}{
+		{"0000-0002-1825", LengthError, "invalid ORCID iD length: 12"},
+		{"0000-00A2-1825-0097", CharError, "invalid character in ORCID iD at position 6"},
+		{"0000-0002-1825-009Y", CharError, "invalid check digit in ORCID iD"},
+		{"0000-0002-1825-0099", ChecksumError, "invalid ORCID iD checksum"},
+	}
+
+	for _, tt := range tests {
+		err := ValidateOrcidID(tt.orcidID)
+		var validationErr *OrcidValidationError
+		if !errors.As(err, &validationErr) {
+			t.Errorf("%s: expected *OrcidValidationError, got %v", tt.orcidID, err)
+			continue
+		}
+		if validationErr.Reason != tt.reason {
+			t.Errorf("%s: expected reason %d, got %d", tt.orcidID, tt.reason, validationErr.Reason)
+		}
+		if err.Error() != tt.message {
+			t.Errorf("%s: expected message %q, got %q", tt.orcidID, tt.message, err.Error())
+		}
+	}
+}
+
+func TestResolveOrcidID(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+		reason   OrcidValidationReason
+	}{
+		{"0000-0002-1825-0097", "0000-0002-1825-0097", 0},
+		{"https://orcid.org/0000-0002-1694-233x?lang=en", "0000-0002-1694-233X", 0},
+		{" 000000021694233x ", "0000-0002-1694-233X", 0},
+		{"0000-0002-1825-0098", "", ChecksumError},
+		{"https://orcid.org/0000-0002-1825", "", LengthError},
+	}
+
+	for _, tt := range tests {
+		id, err := ResolveOrcidID(tt.input)
+		if id != tt.expected {
+			t.Errorf("ResolveOrcidID(%q): expected %q, got %q", tt.input, tt.expected, id)
+		}
+		if tt.reason == 0 {
+			if err != nil {
+				t.Errorf("ResolveOrcidID(%q): unexpected error: %v", tt.input, err)
+			}
+			continue
+		}
+		var validationErr *OrcidValidationError
+		if !errors.As(err, &validationErr) || validationErr.Reason != tt.reason {
+			t.Errorf("ResolveOrcidID(%q): expected reason %d, got %v", tt.input, tt.reason, err)
+		}
+	}
+}
+
+func TestCheckDigit(t *testing.T) {
+	tests := []struct {
+		base    string
+		want    string
+		wantErr bool
+	}{
+		{"0000-0002-1825-009", "7", false},
+		{"000000021694233", "X", false},
+		{"0000-0001-5109-370", "0", false},
+		{"0000-0002-1825", "", true},
+		{"0000-0002-1825-00A", "", true},
+	}
+
+	for _, tt := range tests {
+		got, err := CheckDigit(tt.base)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("CheckDigit(%q): unexpected error state: %v", tt.base, err)
+			continue
+		}
+		if got != tt.want {
+			t.Errorf("CheckDigit(%q): expected %q, got %q", tt.base, tt.want, got)
+		}
+		if err == nil && ValidateOrcidID(tt.base+got) != nil {
+			t.Errorf("CheckDigit(%q): %q does not validate", tt.base, tt.base+got)
+		}
+	}
+}
+
 func TestFormatOrcidID(t *testing.T) {
 	tests := []struct {
 		name     string
@@ -476,6 +826,31 @@ func TestFormatOrcidID(t *testing.T) {
 			input:    "0000-0002-1825-009x",
 			expected: "0000-0002-1825-009X",
 		},
+		{
+			name:     "URL with trailing slash",
+			input:    "https://orcid.org/0000-0002-1825-0097/",
+			expected: "0000-0002-1825-0097",
+		},
+		{
+			name:     "URL with query string",
+			input:    "https://orcid.org/0000-0002-1825-0097?lang=en",
+			expected: "0000-0002-1825-0097",
+		},
+		{
+			name:     "URL with trailing slash and fragment",
+			input:    "https://orcid.org/0000-0002-1825-0097/#works",
+			expected: "0000-0002-1825-0097",
+		},
+		{
+			name:     "Unescapable query string",
+			input:    "orcid.org/0000-0002-1825-0097?q=100%",
+			expected: "0000-0002-1825-0097",
+		},
+		{
+			name:     "Multi-byte characters",
+			input:    "000é00021825009",
+			expected: "000É00021825009",
+		},
 	}
 
 	for _, tt := range tests {
@@ -493,13 +868,15 @@ func TestSearchIterator(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		callCount++
 
+		// Pages hold fewer rows than requested, as ORCID's can, so the
+		// iterator must advance by the rows returned
 		start := r.URL.Query().Get("start")
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 
 		if start == "" || start == "0" {
 			w.Write([]byte(`{
-				"num-found": 25,
+				"num-found": 5,
 				"start": 0,
 				"num-rows": 10,
 				"result": [
@@ -507,25 +884,28 @@ func TestSearchIterator(t *testing.T) {
 					{"orcid-identifier": {"path": "0000-0000-0000-0002"}}
 				]
 			}`))
-		} else if start == "10" {
+		} else if start == "2" {
 			w.Write([]byte(`{
-				"num-found": 25,
-				"start": 10,
+				"num-found": 5,
+				"start": 2,
 				"num-rows": 10,
 				"result": [
 					{"orcid-identifier": {"path": "0000-0000-0000-0003"}},
 					{"orcid-identifier": {"path": "0000-0000-0000-0004"}}
 				]
 			}`))
-		} else {
+		} else if start == "4" {
 			w.Write([]byte(`{
-				"num-found": 25,
-				"start": 20,
+				"num-found": 5,
+				"start": 4,
 				"num-rows": 10,
 				"result": [
 					{"orcid-identifier": {"path": "0000-0000-0000-0005"}}
 				]
 			}`))
+		} else {
+			t.Errorf("Unexpected start %s", start)
+			w.Write([]byte(`{"num-found": 5, "result": []}`))
 		}
 	}))
 	defer server.Close()
@@ -554,17 +934,79 @@ func TestSearchIterator(t *testing.T) {
 	if iter.Error() != nil {
 		t.Fatalf("Unexpected error: %v", iter.Error())
 	}
-	if iter.TotalResults() != 25 {
-		t.Errorf("Expected 25 total results, got %d", iter.TotalResults())
+	if iter.TotalResults() != 5 {
+		t.Errorf("Expected 5 total results, got %d", iter.TotalResults())
 	}
-	if len(results) != 5 {
-		t.Errorf("Expected 5 results, got %d", len(results))
+	expected := []string{"0000-0000-0000-0001", "0000-0000-0000-0002", "0000-0000-0000-0003",
+		"0000-0000-0000-0004", "0000-0000-0000-0005"}
+	if strings.Join(results, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected results %v, got %v", expected, results)
 	}
 	if callCount != 3 {
 		t.Errorf("Expected 3 API calls, got %d", callCount)
 	}
 }
 
+func TestSearchIteratorShortPages(t *testing.T) {
+	var starts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// The second page holds fewer rows than requested. It reports
+		// num-rows as the requested page size, which must not be used to
+		// advance the offset
+		start := r.URL.Query().Get("start")
+		starts = append(starts, start)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		switch start {
+		case "":
+			w.Write([]byte(`{"num-found": 7, "result": [
+				{"orcid-identifier": {"path": "0000-0000-0000-0001"}},
+				{"orcid-identifier": {"path": "0000-0000-0000-0002"}},
+				{"orcid-identifier": {"path": "0000-0000-0000-0003"}}
+			]}`))
+		case "3":
+			w.Write([]byte(`{"num-found": 7, "num-rows": 3, "result": [
+				{"orcid-identifier": {"path": "0000-0000-0000-0004"}}
+			]}`))
+		case "4":
+			w.Write([]byte(`{"num-found": 7, "result": [
+				{"orcid-identifier": {"path": "0000-0000-0000-0005"}},
+				{"orcid-identifier": {"path": "0000-0000-0000-0006"}},
+				{"orcid-identifier": {"path": "0000-0000-0000-0007"}}
+			]}`))
+		default:
+			t.Errorf("Unexpected start %s", start)
+			w.Write([]byte(`{"num-found": 7, "result": []}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(100),
+	)
+
+	iter := client.SearchIter(context.Background(), SearchParams{Query: "test", Rows: 3})
+	var results []string
+	for iter.Next() {
+		results = append(results, string(iter.Value().OrcidIdentifier.Path))
+	}
+	if err := iter.Error(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{"0000-0000-0000-0001", "0000-0000-0000-0002", "0000-0000-0000-0003",
+		"0000-0000-0000-0004", "0000-0000-0000-0005", "0000-0000-0000-0006", "0000-0000-0000-0007"}
+	if strings.Join(results, ",") != strings.Join(expected, ",") {
+		t.Errorf("Expected results %v, got %v", expected, results)
+	}
+	if fmt.Sprint(starts) != "[ 3 4]" {
+		t.Errorf("Expected starts [ 3 4], got %v", starts)
+	}
+}
+
 func TestErrorHandling(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -617,32 +1059,146 @@ func TestErrorHandling(t *testing.T) {
 	}
 }
 
-func TestGetRecordRaw(t *testing.T) {
-	expectedJSON := `{
-		"orcid-identifier": {
-			"uri": "https://orcid.org/0000-0002-1825-0097",
-			"path": "0000-0002-1825-0097",
-			"host": "orcid.org"
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name             string
+		statusCode       int
+		maxRetries       int
+		responseBody     string
+		errorCode        int
+		developerMessage string
+	}{
+		{
+			name:             "JSON error body",
+			statusCode:       http.StatusConflict,
+			responseBody:     `{"response-code":409,"developer-message":"409 Conflict: duplicate external identifier","user-message":"Duplicate","error-code":9021}`,
+			errorCode:        9021,
+			developerMessage: "409 Conflict: duplicate external identifier",
 		},
-		"person": {
-			"name": {
-				"given-names": {"value": "John"},
-				"family-name": {"value": "Doe"}
-			}
-		}
-	}`
+		{
+			name:             "XML error body",
+			statusCode:       http.StatusBadRequest,
+			responseBody:     `<error:error xmlns:error="http://www.orcid.org/ns/error"><error:response-code>400</error:response-code><error:developer-message>Invalid put-code</error:developer-message><error:error-code>9006</error:error-code></error:error>`,
+			errorCode:        9006,
+			developerMessage: "Invalid put-code",
+		},
+		{
+			name:         "Unparseable body",
+			statusCode:   http.StatusForbidden,
+			responseBody: `Forbidden`,
+		},
+		{
+			name:             "Retries exhausted",
+			statusCode:       http.StatusServiceUnavailable,
+			responseBody:     `{"developer-message":"Service down","error-code":9999}`,
+			errorCode:        9999,
+			developerMessage: "Service down",
+		},
+	}
 
-	expectedXML := `<?xml version="1.0" encoding="UTF-8"?>
-<record:record xmlns:record="http://www.orcid.org/ns/record">
-	<record:orcid-identifier>
-		<common:uri>https://orcid.org/0000-0002-1825-0097</common:uri>
-		<common:path>0000-0002-1825-0097</common:path>
-		<common:host>orcid.org</common:host>
-	</record:orcid-identifier>
-</record:record>`
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+				w.Write([]byte(tt.responseBody))
+			}))
+			defer server.Close()
 
-	t.Run("JSON response", func(t *testing.T) {
-		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			client := NewClient(
+				WithAPIURL(server.URL+"/v3.0"),
+				WithBearerToken("test-token"),
+				WithMaxRetries(tt.maxRetries),
+			)
+
+			_, err := client.GetRecord(context.Background(), "0000-0002-1825-0097")
+			var apiErr *APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Expected *APIError, got %T: %v", err, err)
+			}
+			if apiErr.StatusCode != tt.statusCode {
+				t.Errorf("Expected status code %d, got %d", tt.statusCode, apiErr.StatusCode)
+			}
+			if apiErr.ORCIDErrorCode != tt.errorCode {
+				t.Errorf("Expected ORCID error code %d, got %d", tt.errorCode, apiErr.ORCIDErrorCode)
+			}
+			if apiErr.DeveloperMessage != tt.developerMessage {
+				t.Errorf("Expected developer message %q, got %q", tt.developerMessage, apiErr.DeveloperMessage)
+			}
+			if string(apiErr.Raw) != tt.responseBody {
+				t.Errorf("Expected raw body %q, got %q", tt.responseBody, apiErr.Raw)
+			}
+			if errors.Is(err, ErrNotFound) {
+				t.Errorf("Did not expect HTTP %d to match ErrNotFound", tt.statusCode)
+			}
+		})
+	}
+}
+
+func TestSentinelErrors(t *testing.T) {
+	sentinels := []error{ErrNotFound, ErrUnauthorized, ErrRateLimited}
+	tests := []struct {
+		statusCode int
+		want       error
+	}{
+		{http.StatusNotFound, ErrNotFound},
+		{http.StatusUnauthorized, ErrUnauthorized},
+		{http.StatusForbidden, ErrUnauthorized},
+		{http.StatusTooManyRequests, ErrRateLimited},
+		{http.StatusBadRequest, nil},
+	}
+
+	for _, tt := range tests {
+		t.Run(http.StatusText(tt.statusCode), func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			client := NewClient(
+				WithAPIURL(server.URL+"/v3.0"),
+				WithBearerToken("test-token"),
+				WithMaxRetries(0),
+			)
+
+			_, err := client.GetRecord(context.Background(), "0000-0002-1825-0097")
+			if err == nil {
+				t.Fatal("Expected error")
+			}
+			for _, sentinel := range sentinels {
+				if got := errors.Is(err, sentinel); got != (sentinel == tt.want) {
+					t.Errorf("errors.Is(%v, %v) = %v", err, sentinel, got)
+				}
+			}
+		})
+	}
+}
+
+func TestGetRecordRaw(t *testing.T) {
+	expectedJSON := `{
+		"orcid-identifier": {
+			"uri": "https://orcid.org/0000-0002-1825-0097",
+			"path": "0000-0002-1825-0097",
+			"host": "orcid.org"
+		},
+		"person": {
+			"name": {
+				"given-names": {"value": "John"},
+				"family-name": {"value": "Doe"}
+			}
+		}
+	}`
+
+	expectedXML := `<?xml version="1.0" encoding="UTF-8"?>
+<record:record xmlns:record="http://www.orcid.org/ns/record">
+	<record:orcid-identifier>
+		<common:uri>https://orcid.org/0000-0002-1825-0097</common:uri>
+		<common:path>0000-0002-1825-0097</common:path>
+		<common:host>orcid.org</common:host>
+	</record:orcid-identifier>
+</record:record>`
+
+	t.Run("JSON response", func(t *testing.T) {
+		server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			if r.Header.Get("Accept") != "application/json" {
 				t.Errorf("Expected Accept header %s, got %s", "application/json", r.Header.Get("Accept"))
 			}
@@ -902,21 +1458,20 @@ func TestGetByPath(t *testing.T) {
 	}
 }
 
-func TestGetByPathBiographyFromPerson(t *testing.T) {
+func TestGetByPathBiography(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// GetByPath for biography should fetch the person record
-		if r.URL.Path != "/v3.0/0000-0002-1825-0097/person" {
-			t.Errorf("Expected path %s, got %s", "/v3.0/0000-0002-1825-0097/person", r.URL.Path)
+		// GetByPath for biography should use the biography endpoint rather
+		// than fetch the person record
+		if r.URL.Path != "/v3.0/0000-0002-1825-0097/biography" {
+			t.Errorf("Expected path %s, got %s", "/v3.0/0000-0002-1825-0097/biography", r.URL.Path)
 		}
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{
-			"biography": {
-				"content": "Test biography",
-				"visibility": "public",
-				"path": "/0000-0002-1825-0097/biography"
-			}
+			"content": "Test biography",
+			"visibility": "public",
+			"path": "/0000-0002-1825-0097/biography"
 		}`))
 	}))
 	defer server.Close()
@@ -941,22 +1496,21 @@ func TestGetByPathBiographyFromPerson(t *testing.T) {
 	}
 }
 
-func TestGetByPathActivitiesFromRecord(t *testing.T) {
+func TestGetByPathActivities(t *testing.T) {
 	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
-		// GetByPath for activities should fetch the full record
-		if r.URL.Path != "/v3.0/0000-0002-1825-0097/record" {
-			t.Errorf("Expected path %s, got %s", "/v3.0/0000-0002-1825-0097/record", r.URL.Path)
+		// GetByPath for activities should use the activities endpoint
+		// rather than fetch the full record
+		if r.URL.Path != "/v3.0/0000-0002-1825-0097/activities" {
+			t.Errorf("Expected path %s, got %s", "/v3.0/0000-0002-1825-0097/activities", r.URL.Path)
 		}
 
 		w.Header().Set("Content-Type", "application/json")
 		w.WriteHeader(http.StatusOK)
 		w.Write([]byte(`{
-			"orcid-identifier": {"path": "0000-0002-1825-0097"},
-			"activities-summary": {
-				"works": {
-					"path": "/0000-0002-1825-0097/works"
-				}
-			}
+			"works": {
+				"path": "/0000-0002-1825-0097/works"
+			},
+			"path": "/0000-0002-1825-0097/activities"
 		}`))
 	}))
 	defer server.Close()
@@ -979,4 +1533,1130 @@ func TestGetByPathActivitiesFromRecord(t *testing.T) {
 	if activities.Works == nil {
 		t.Error("Expected non-nil Works in activities summary")
 	}
+
+	activities, err = client.GetActivities(ctx, "0000-0002-1825-0097")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if activities.Path != "/0000-0002-1825-0097/activities" {
+		t.Errorf("Expected activities path, got %q", activities.Path)
+	}
+}
+
+func TestRedirectPreservesBearerToken(t *testing.T) {
+	var redirectedAuth string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v3.0/0000000218250097/record" {
+			http.Redirect(w, r, "/v3.0/0000-0002-1825-0097/record", http.StatusMovedPermanently)
+			return
+		}
+		redirectedAuth = r.Header.Get("Authorization")
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"orcid-identifier": {"path": "0000-0002-1825-0097"}}`))
+	}))
+	defer server.Close()
+
+	var info ResponseInfo
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithResponseHook(func(i ResponseInfo) { info = i }),
+	)
+	ctx := context.Background()
+
+	record, err := client.GetRecord(ctx, "0000000218250097")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if record.OrcidIdentifier.Path != "0000-0002-1825-0097" {
+		t.Errorf("Expected path %s, got %s", "0000-0002-1825-0097", record.OrcidIdentifier.Path)
+	}
+	if redirectedAuth != "Bearer test-token" {
+		t.Errorf("Expected Authorization header to survive redirect, got '%s'", redirectedAuth)
+	}
+	if info.URL != server.URL+"/v3.0/0000000218250097/record" {
+		t.Errorf("Expected the requested URL in the response info, got '%s'", info.URL)
+	}
+	if info.FinalURL != server.URL+"/v3.0/0000-0002-1825-0097/record" {
+		t.Errorf("Expected the redirect target as final URL, got '%s'", info.FinalURL)
+	}
+}
+
+func TestRedirectToDifferentOrcidID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/v3.0/0000-0002-1825-0097/record" {
+			http.Redirect(w, r, "/v3.0/0000-0001-5109-3700/record", http.StatusMovedPermanently)
+			return
+		}
+		t.Errorf("Unexpected request to %s", r.URL.Path)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+	ctx := context.Background()
+
+	_, err := client.GetRecord(ctx, "0000-0002-1825-0097")
+	if err == nil {
+		t.Fatal("Expected error for redirect to a different ORCID iD")
+	}
+	if !strings.Contains(err.Error(), "0000-0001-5109-3700") {
+		t.Errorf("Expected error to mention the redirect target, got: %v", err)
+	}
+}
+
+func TestCheckRedirectORCIDSubdomain(t *testing.T) {
+	original, _ := http.NewRequest(http.MethodGet, "https://pub.orcid.org/v3.0/0000-0002-1825-0097/record", nil)
+	original.Header.Set("Authorization", "Bearer test-token")
+
+	redirected, _ := http.NewRequest(http.MethodGet, "https://orcid.org/v3.0/0000-0002-1825-0097/record", nil)
+	if err := checkRedirect(redirected, []*http.Request{original}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if redirected.Header.Get("Authorization") != "Bearer test-token" {
+		t.Errorf("Expected Authorization header on orcid.org redirect, got '%s'", redirected.Header.Get("Authorization"))
+	}
+
+	foreign, _ := http.NewRequest(http.MethodGet, "https://example.com/v3.0/0000-0002-1825-0097/record", nil)
+	if err := checkRedirect(foreign, []*http.Request{original}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if foreign.Header.Get("Authorization") != "" {
+		t.Error("Expected Authorization header not to be forwarded to a foreign host")
+	}
+}
+
+func TestFetchAll(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v3.0/0000-0002-1825-0097/works":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"group": [{"work-summary": [{"put-code": 1}, {"put-code": 2}]}]}`))
+		case "/v3.0/0000-0002-1825-0097/works/1,2":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"bulk": [{"work": {"put-code": 1, "title": {"title": {"value": "Work 1"}}}}, {"work": {"put-code": 2, "title": {"title": {"value": "Work 2"}}}}]}`))
+		case "/v3.0/0000-0002-1825-0097/employments":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"employment-summary": [{"put-code": 3, "role-title": "Professor"}]}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+	ctx := context.Background()
+
+	result, err := client.FetchAll(ctx, "0000-0002-1825-0097", "works")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	works, ok := result.([]*Work)
+	if !ok {
+		t.Fatalf("Expected []*Work, got %T", result)
+	}
+	if len(works) != 2 {
+		t.Fatalf("Expected 2 full works, got %d", len(works))
+	}
+	for i, work := range works {
+		if title := fmt.Sprintf("Work %d", i+1); work.Title == nil || work.Title.Title == nil || work.Title.Title.Value != title {
+			t.Errorf("Expected work %d to have its full details with title %q, got %+v", i, title, work)
+		}
+	}
+
+	result, err = client.FetchAll(ctx, "0000-0002-1825-0097", "employments")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	employments, ok := result.(*Employments)
+	if !ok {
+		t.Fatalf("Expected *Employments, got %T", result)
+	}
+	if len(employments.EmploymentSummary) != 1 || employments.EmploymentSummary[0].RoleTitle != "Professor" {
+		t.Errorf("Expected 1 employment with role Professor, got %+v", employments.EmploymentSummary)
+	}
+
+	_, err = client.FetchAll(ctx, "0000-0002-1825-0097", "unknown")
+	if err == nil || !strings.Contains(err.Error(), "unsupported section") {
+		t.Errorf("Expected unsupported section error, got: %v", err)
+	}
+}
+
+func TestSearchCursor(t *testing.T) {
+	pages := map[string]string{
+		"*":  `{"num-found": 5, "next-cursor": "c1", "result": [{"orcid-identifier": {"path": "0000-0000-0000-0001"}}, {"orcid-identifier": {"path": "0000-0000-0000-0002"}}]}`,
+		"c1": `{"num-found": 5, "next-cursor": "c2", "result": [{"orcid-identifier": {"path": "0000-0000-0000-0003"}}, {"orcid-identifier": {"path": "0000-0000-0000-0004"}}]}`,
+		"c2": `{"num-found": 5, "next-cursor": "c3", "result": [{"orcid-identifier": {"path": "0000-0000-0000-0005"}}]}`,
+		"c3": `{"num-found": 5, "next-cursor": "c3", "result": []}`,
+	}
+	var cursors []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		cursors = append(cursors, query.Get("cursor"))
+		if query.Has("start") {
+			t.Errorf("Expected no start parameter with a cursor, got %s", query.Get("start"))
+		}
+		if query.Get("sort") != "orcid asc" {
+			t.Errorf("Expected sort %s, got %s", "orcid asc", query.Get("sort"))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(pages[query.Get("cursor")]))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(100),
+	)
+
+	iter := client.SearchCursor(context.Background(), NewSearchQuery().
+		FamilyName("Smith").
+		WithStart(20).
+		WithRows(2).
+		SortBy("orcid", true))
+
+	var paths []string
+	for iter.Next() {
+		paths = append(paths, string(iter.Value().OrcidIdentifier.Path))
+	}
+	if err := iter.Error(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(paths) != 5 || paths[0] != "0000-0000-0000-0001" || paths[4] != "0000-0000-0000-0005" {
+		t.Errorf("Expected 5 results in order, got %v", paths)
+	}
+	if fmt.Sprint(cursors) != "[* c1 c2 c3]" {
+		t.Errorf("Expected cursors [* c1 c2 c3], got %v", cursors)
+	}
+	if iter.TotalResults() != 5 {
+		t.Errorf("Expected total results %d, got %d", 5, iter.TotalResults())
+	}
+}
+
+func TestSearchScore(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		if strings.HasPrefix(r.URL.Path, "/v3.0/expanded-search") {
+			w.Write([]byte(`{
+				"num-found": 2,
+				"expanded-result": [
+					{"orcid-id": "0000-0000-0000-0001", "score": 12.5},
+					{"orcid-id": "0000-0000-0000-0002"}
+				]
+			}`))
+			return
+		}
+		w.Write([]byte(`{
+			"num-found": 2,
+			"result": [
+				{"orcid-identifier": {"path": "0000-0000-0000-0001"}, "score": 3.25},
+				{"orcid-identifier": {"path": "0000-0000-0000-0002"}}
+			]
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+	ctx := context.Background()
+
+	result, err := client.Search(ctx, SearchParams{Query: "test"})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.Results[0].Score != 3.25 {
+		t.Errorf("Expected score %v, got %v", 3.25, result.Results[0].Score)
+	}
+	if result.Results[1].Score != 0 {
+		t.Errorf("Expected zero score when absent, got %v", result.Results[1].Score)
+	}
+
+	expanded, err := client.ExpandedSearch(ctx, "test")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if expanded.ExpandedResults[0].Score != 12.5 {
+		t.Errorf("Expected score %v, got %v", 12.5, expanded.ExpandedResults[0].Score)
+	}
+	if expanded.ExpandedResults[1].Score != 0 {
+		t.Errorf("Expected zero score when absent, got %v", expanded.ExpandedResults[1].Score)
+	}
+}
+
+func TestSearchIteratorMaxResults(t *testing.T) {
+	var requests int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+		var results []string
+		for i := start; i < start+2; i++ {
+			results = append(results, fmt.Sprintf(`{"orcid-identifier": {"path": "0000-0000-0000-%04d"}}`, i))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		fmt.Fprintf(w, `{"num-found": 100000, "start": %d, "num-rows": 2, "result": [%s]}`,
+			start, strings.Join(results, ","))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(0),
+	)
+	defer client.Close()
+
+	iter := client.SearchIter(context.Background(), SearchParams{Query: "test", Rows: 2}).WithMaxResults(5)
+	count := 0
+	for iter.Next() {
+		count++
+	}
+	if iter.Error() != nil {
+		t.Fatalf("Unexpected error: %v", iter.Error())
+	}
+	if count != 5 {
+		t.Errorf("Expected 5 results, got %d", count)
+	}
+	if requests != 3 {
+		t.Errorf("Expected 3 page requests, got %d", requests)
+	}
+	if iter.Next() {
+		t.Error("Expected Next to stay false after the limit")
+	}
+}
+
+func TestExpandedSearchIter(t *testing.T) {
+	var starts, rows []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3.0/expanded-search/" {
+			t.Errorf("Expected path %s, got %s", "/v3.0/expanded-search/", r.URL.Path)
+		}
+		query := r.URL.Query()
+		if query.Get("q") != "family-name:Smith" {
+			t.Errorf("Expected query %s, got %s", "family-name:Smith", query.Get("q"))
+		}
+		starts = append(starts, query.Get("start"))
+		rows = append(rows, query.Get("rows"))
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		switch query.Get("start") {
+		case "":
+			w.Write([]byte(`{"num-found": 5, "expanded-result": [{"orcid-id": "0000-0000-0000-0001", "family-names": "Smith"}, {"orcid-id": "0000-0000-0000-0002"}]}`))
+		case "2":
+			w.Write([]byte(`{"num-found": 5, "expanded-result": [{"orcid-id": "0000-0000-0000-0003"}, {"orcid-id": "0000-0000-0000-0004"}]}`))
+		case "4":
+			w.Write([]byte(`{"num-found": 5, "expanded-result": [{"orcid-id": "0000-0000-0000-0005", "institution-name": ["MIT"]}]}`))
+		default:
+			t.Errorf("Unexpected start %s", query.Get("start"))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(100),
+	)
+
+	iter := client.ExpandedSearchIter(context.Background(), ExpandedSearchParams{Query: "family-name:Smith", Rows: 2})
+	var ids []string
+	for iter.Next() {
+		ids = append(ids, iter.Value().OrcidID)
+	}
+	if err := iter.Error(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(ids) != 5 || ids[0] != "0000-0000-0000-0001" || ids[4] != "0000-0000-0000-0005" {
+		t.Errorf("Expected 5 results in order, got %v", ids)
+	}
+	if fmt.Sprint(starts) != "[ 2 4]" || fmt.Sprint(rows) != "[2 2 2]" {
+		t.Errorf("Expected starts [ 2 4] and rows [2 2 2], got %v and %v", starts, rows)
+	}
+	if iter.TotalResults() != 5 {
+		t.Errorf("Expected total results %d, got %d", 5, iter.TotalResults())
+	}
+}
+
+func TestExpandedSearchIterShortPages(t *testing.T) {
+	var starts []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		start := r.URL.Query().Get("start")
+		starts = append(starts, start)
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+
+		// The second page holds fewer rows than requested
+		switch start {
+		case "":
+			w.Write([]byte(`{"num-found": 5, "expanded-result": [{"orcid-id": "0000-0000-0000-0001"}, {"orcid-id": "0000-0000-0000-0002"}]}`))
+		case "2":
+			w.Write([]byte(`{"num-found": 5, "expanded-result": [{"orcid-id": "0000-0000-0000-0003"}]}`))
+		case "3":
+			w.Write([]byte(`{"num-found": 5, "expanded-result": [{"orcid-id": "0000-0000-0000-0004"}, {"orcid-id": "0000-0000-0000-0005"}]}`))
+		default:
+			t.Errorf("Unexpected start %s", start)
+			w.Write([]byte(`{"num-found": 5}`))
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(100),
+	)
+
+	iter := client.ExpandedSearchIter(context.Background(), ExpandedSearchParams{Query: "family-name:Smith", Rows: 2})
+	var ids []string
+	for iter.Next() {
+		ids = append(ids, iter.Value().OrcidID)
+	}
+	if err := iter.Error(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "[0000-0000-0000-0001 0000-0000-0000-0002 0000-0000-0000-0003 0000-0000-0000-0004 0000-0000-0000-0005]"
+	if fmt.Sprint(ids) != expected {
+		t.Errorf("Expected results %s, got %v", expected, ids)
+	}
+	if fmt.Sprint(starts) != "[ 2 3]" {
+		t.Errorf("Expected starts [ 2 3], got %v", starts)
+	}
+}
+
+func TestClientClose(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"orcid-identifier": {"path": "0000-0002-1825-0097"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithPerResourceRateLimit(5),
+	)
+	ctx := context.Background()
+
+	if _, err := client.GetRecord(ctx, "0000-0002-1825-0097"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if err := client.Close(); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := client.Close(); err != nil {
+		t.Fatalf("Expected second Close to succeed, got: %v", err)
+	}
+	if len(client.resourceLimiter.next) != 0 {
+		t.Errorf("Expected per-resource limiter state to be cleared, got %d entries", len(client.resourceLimiter.next))
+	}
+
+	_, err := client.GetRecord(ctx, "0000-0002-1825-0097")
+	if err == nil || !strings.Contains(err.Error(), "client is closed") {
+		t.Errorf("Expected client is closed error, got: %v", err)
+	}
+	if requests != 1 {
+		t.Errorf("Expected 1 request to reach the server, got %d", requests)
+	}
+}
+
+func TestBatchSearch(t *testing.T) {
+	counts := map[string]int{
+		"family-name:Smith": 7,
+		"family-name:Jones": 3,
+		"family-name:Brown": 11,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count, ok := counts[r.URL.Query().Get("q")]
+		if !ok {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(fmt.Sprintf(`{"num-found": %d, "result": []}`, count)))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(100),
+	)
+	ctx := context.Background()
+
+	queries := []*SearchQuery{
+		NewSearchQuery().FamilyName("Smith"),
+		NewSearchQuery().FamilyName("Jones"),
+		NewSearchQuery().FamilyName("Brown"),
+	}
+
+	results, errs := client.BatchSearch(ctx, queries)
+	if len(results) != 3 || len(errs) != 3 {
+		t.Fatalf("Expected 3 results and 3 errors, got %d and %d", len(results), len(errs))
+	}
+	for i, expected := range []int{7, 3, 11} {
+		if errs[i] != nil {
+			t.Errorf("Unexpected error for query %d: %v", i, errs[i])
+			continue
+		}
+		if results[i].NumFound != expected {
+			t.Errorf("Expected NumFound %d for query %d, got %d", expected, i, results[i].NumFound)
+		}
+	}
+}
+
+func TestNewClientWithError(t *testing.T) {
+	client, err := NewClientWithError(WithContentType(ContentTypeXML))
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if client.contentType != ContentTypeXML {
+		t.Errorf("Expected contentType %s, got %s", ContentTypeXML, client.contentType)
+	}
+
+	_, err = NewClientWithError(WithContentType(ContentType("text/bogus")))
+	if err == nil {
+		t.Fatal("Expected error for unsupported content type")
+	}
+	if !strings.Contains(err.Error(), "unsupported content type") {
+		t.Errorf("Expected unsupported content type error, got: %v", err)
+	}
+}
+
+func TestGetRecordResolved(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/v3.0/0000-0002-1825-0097/record":
+			w.Header().Set("Location", "/v3.0/0000-0001-5109-3700/record")
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusMovedPermanently)
+			w.Write([]byte(`{"response-code": 301, "developer-message": "This account is deprecated. Please refer to account: 0000-0001-5109-3700", "error-code": 9007}`))
+		case "/v3.0/0000-0001-5109-3700/record":
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"orcid-identifier": {"path": "0000-0001-5109-3700"}}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+	ctx := context.Background()
+
+	record, resolvedID, err := client.GetRecordResolved(ctx, "0000-0002-1825-0097")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resolvedID != "0000-0001-5109-3700" {
+		t.Errorf("Expected resolved ID %s, got %s", "0000-0001-5109-3700", resolvedID)
+	}
+	if record.OrcidIdentifier.Path != "0000-0001-5109-3700" {
+		t.Errorf("Expected record for %s, got %s", "0000-0001-5109-3700", record.OrcidIdentifier.Path)
+	}
+
+	record, resolvedID, err = client.GetRecordResolved(ctx, "0000-0001-5109-3700")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if resolvedID != "0000-0001-5109-3700" {
+		t.Errorf("Expected unchanged ID %s, got %s", "0000-0001-5109-3700", resolvedID)
+	}
+}
+
+func TestDeprecatedRecord(t *testing.T) {
+	tests := []struct {
+		name     string
+		location string
+		body     string
+	}{
+		{
+			name:     "Location header",
+			location: "/v3.0/0000-0001-5109-3700/record",
+			body:     `{"response-code": 301, "error-code": 9007}`,
+		},
+		{
+			name: "Body only",
+			body: `{"response-code": 301, "developer-message": "301 Moved Permanently: This account is deprecated. Please refer to account: https://orcid.org/0000-0001-5109-3700. ORCID 0000-0002-1825-0097", "error-code": 9007}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				if tt.location != "" {
+					w.Header().Set("Location", tt.location)
+				}
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(http.StatusMovedPermanently)
+				w.Write([]byte(tt.body))
+			}))
+			defer server.Close()
+
+			client := NewClient(
+				WithAPIURL(server.URL+"/v3.0"),
+				WithBearerToken("test-token"),
+			)
+
+			_, err := client.GetRecord(context.Background(), "0000-0002-1825-0097")
+			var deprecated *ErrDeprecatedRecord
+			if !errors.As(err, &deprecated) {
+				t.Fatalf("Expected *ErrDeprecatedRecord, got %T: %v", err, err)
+			}
+			if deprecated.ID != "0000-0002-1825-0097" {
+				t.Errorf("Expected ID %s, got %s", "0000-0002-1825-0097", deprecated.ID)
+			}
+			if deprecated.PrimaryID != "0000-0001-5109-3700" {
+				t.Errorf("Expected PrimaryID %s, got %s", "0000-0001-5109-3700", deprecated.PrimaryID)
+			}
+			if deprecated.StatusCode != http.StatusMovedPermanently {
+				t.Errorf("Expected status code %d, got %d", http.StatusMovedPermanently, deprecated.StatusCode)
+			}
+		})
+	}
+}
+
+func TestGetRecordSummary(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3.0/0000-0002-1825-0097/record-summary" {
+			t.Errorf("Expected path %s, got %s", "/v3.0/0000-0002-1825-0097/record-summary", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"created-date": {"value": 1460757617078},
+			"credit-name": "Josiah Carberry",
+			"orcid-identifier": "https://orcid.org/0000-0002-1825-0097",
+			"external-identifiers": {"external-identifier": [
+				{"external-id-type": "Scopus Author ID", "external-id-value": "123456", "validated": true}
+			]},
+			"employments": {"count": 3, "affiliation": [
+				{"put-code": 11, "type": "employment", "organization-name": "Brown University", "role": "Professor", "start-date": {"year": {"value": "2001"}}}
+			]},
+			"fundings": {"self-asserted-count": 1, "validated-count": 2},
+			"peer-reviews": {"peer-review-publication-grants": 4, "self-asserted-count": 0, "total": 9},
+			"works": {"self-asserted-count": 5, "validated-count": 20}
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+
+	summary, err := client.GetRecordSummary(context.Background(), "0000-0002-1825-0097")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if summary.CreditName != "Josiah Carberry" {
+		t.Errorf("Expected credit name %s, got %s", "Josiah Carberry", summary.CreditName)
+	}
+	if len(summary.ExternalIdentifiers.ExternalIdentifier) != 1 || !summary.ExternalIdentifiers.ExternalIdentifier[0].Validated {
+		t.Errorf("Expected 1 validated external identifier, got %+v", summary.ExternalIdentifiers)
+	}
+	if summary.Employments.Count != 3 || summary.Employments.Affiliation[0].OrganizationName != "Brown University" {
+		t.Errorf("Unexpected employments: %+v", summary.Employments)
+	}
+	if summary.Works.Total() != 25 {
+		t.Errorf("Expected 25 works, got %d", summary.Works.Total())
+	}
+	if summary.Fundings.Total() != 3 {
+		t.Errorf("Expected 3 fundings, got %d", summary.Fundings.Total())
+	}
+	if summary.PeerReviews.Total != 9 {
+		t.Errorf("Expected 9 peer reviews, got %d", summary.PeerReviews.Total)
+	}
+}
+
+func TestGetPersonSections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/v3.0/0000-0002-1825-0097/biography":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"content": "Josiah Carberry is a fictitious person.", "visibility": "public"}`))
+		case "/v3.0/0000-0002-1825-0097/keywords":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"keyword": [{"content": "psychoceramics", "put-code": 1}]}`))
+		case "/v3.0/0000-0002-1825-0097/email":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"email": [{"email": "josiah@example.org", "primary": true}]}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+	ctx := context.Background()
+
+	biography, err := client.GetBiography(ctx, "0000-0002-1825-0097")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if biography.Content != "Josiah Carberry is a fictitious person." {
+		t.Errorf("Unexpected biography: %s", biography.Content)
+	}
+
+	keywords, err := client.GetKeywords(ctx, "0000-0002-1825-0097")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(keywords.Keyword) != 1 || keywords.Keyword[0].Content != "psychoceramics" {
+		t.Errorf("Unexpected keywords: %+v", keywords.Keyword)
+	}
+
+	emails, err := client.GetEmails(ctx, "0000-0002-1825-0097")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(emails.Email) != 1 || emails.Email[0].Email != "josiah@example.org" {
+		t.Errorf("Unexpected emails: %+v", emails.Email)
+	}
+}
+
+func TestGetByPathSingleItems(t *testing.T) {
+	tests := []struct {
+		path         Path
+		expectedPath string
+		check        func(interface{}) bool
+	}{
+		{"/0000-0002-1825-0097/education/1", "/v3.0/0000-0002-1825-0097/education/1", func(v interface{}) bool { _, ok := v.(*EducationSummary); return ok }},
+		{"/0000-0002-1825-0097/educations/1", "/v3.0/0000-0002-1825-0097/education/1", func(v interface{}) bool { _, ok := v.(*EducationSummary); return ok }},
+		{"/0000-0002-1825-0097/employment/2", "/v3.0/0000-0002-1825-0097/employment/2", func(v interface{}) bool { _, ok := v.(*EmploymentSummary); return ok }},
+		{"/0000-0002-1825-0097/funding/3", "/v3.0/0000-0002-1825-0097/funding/3", func(v interface{}) bool { _, ok := v.(*FundingSummary); return ok }},
+		{"/0000-0002-1825-0097/peer-review/4", "/v3.0/0000-0002-1825-0097/peer-review/4", func(v interface{}) bool { _, ok := v.(*PeerReviewSummary); return ok }},
+		{"/0000-0002-1825-0097/distinction/5", "/v3.0/0000-0002-1825-0097/distinction/5", func(v interface{}) bool { _, ok := v.(*DistinctionSummary); return ok }},
+		{"/0000-0002-1825-0097/invited-position/6", "/v3.0/0000-0002-1825-0097/invited-position/6", func(v interface{}) bool { _, ok := v.(*InvitedPositionSummary); return ok }},
+		{"/0000-0002-1825-0097/membership/7", "/v3.0/0000-0002-1825-0097/membership/7", func(v interface{}) bool { _, ok := v.(*MembershipSummary); return ok }},
+		{"/0000-0002-1825-0097/qualification/8", "/v3.0/0000-0002-1825-0097/qualification/8", func(v interface{}) bool { _, ok := v.(*QualificationSummary); return ok }},
+		{"/0000-0002-1825-0097/service/9", "/v3.0/0000-0002-1825-0097/service/9", func(v interface{}) bool { _, ok := v.(*ServiceSummary); return ok }},
+	}
+
+	var actualPath string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		actualPath = r.URL.Path
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"put-code": 1}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(100),
+	)
+	ctx := context.Background()
+
+	for _, tt := range tests {
+		result, err := client.GetByPath(ctx, tt.path)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", tt.path, err)
+		}
+		if actualPath != tt.expectedPath {
+			t.Errorf("Expected request to %s for %s, got %s", tt.expectedPath, tt.path, actualPath)
+		}
+		if !tt.check(result) {
+			t.Errorf("Unexpected result type %T for %s", result, tt.path)
+		}
+	}
+
+	if _, err := client.GetByPath(ctx, "/0000-0002-1825-0097/funding"); err == nil || !strings.Contains(err.Error(), "requires put-code") {
+		t.Errorf("Expected put-code error for a single-item path without put-code, got %v", err)
+	}
+	if _, err := client.GetByPath(ctx, "/0000-0002-1825-0097/work/1,2"); err == nil || !strings.Contains(err.Error(), "single put-code") {
+		t.Errorf("Expected single put-code error for a put-code list on a single-item path, got %v", err)
+	}
+}
+
+func TestGetByPathBulkWorks(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3.0/0000-0002-1825-0097/works/1,2,3" {
+			t.Errorf("Expected the bulk works endpoint, got %s", r.URL.Path)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"bulk": [{"work": {"put-code": 1}}, {"work": {"put-code": 2}}, {"work": {"put-code": 3}}]}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+
+	result, err := client.GetByPath(context.Background(), "/0000-0002-1825-0097/works/1,2,3")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	works, ok := result.([]*Work)
+	if !ok {
+		t.Fatalf("Expected []*Work, got %T", result)
+	}
+	if len(works) != 3 || works[2].PutCode != 3 {
+		t.Errorf("Expected works 1, 2 and 3, got %+v", works)
+	}
+}
+
+func TestGetByPathPersonSections(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		// Sections with their own endpoint are fetched from it, the others
+		// from the person record
+		switch r.URL.Path {
+		case "/v3.0/0000-0002-1825-0097/biography":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"content": "Test biography"}`))
+		case "/v3.0/0000-0002-1825-0097/keywords":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"keyword": [{"content": "psychoceramics"}]}`))
+		case "/v3.0/0000-0002-1825-0097/email":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"email": [{"email": "josiah@example.org"}]}`))
+		case "/v3.0/0000-0002-1825-0097/person":
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{
+				"other-names": {"other-name": [{"content": "J. Carberry"}]},
+				"researcher-urls": {"researcher-url": [{"url-name": "Homepage"}]},
+				"addresses": {"address": [{"country": {"value": "US"}}]},
+				"external-identifiers": {"external-identifier": [{"external-id-type": "Scopus Author ID"}]}
+			}`))
+		default:
+			t.Errorf("Unexpected path %s", r.URL.Path)
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(100),
+	)
+	ctx := context.Background()
+
+	tests := []struct {
+		segment string
+		check   func(interface{}) bool
+	}{
+		{"biography", func(v interface{}) bool { b, ok := v.(*Biography); return ok && b != nil }},
+		{"other-names", func(v interface{}) bool { o, ok := v.(*OtherNames); return ok && o != nil }},
+		{"researcher-urls", func(v interface{}) bool { u, ok := v.(*ResearcherURLs); return ok && u != nil }},
+		{"email", func(v interface{}) bool { e, ok := v.(*Emails); return ok && e != nil }},
+		{"emails", func(v interface{}) bool { e, ok := v.(*Emails); return ok && e != nil }},
+		{"address", func(v interface{}) bool { a, ok := v.(*Addresses); return ok && a != nil }},
+		{"addresses", func(v interface{}) bool { a, ok := v.(*Addresses); return ok && a != nil }},
+		{"keywords", func(v interface{}) bool { k, ok := v.(*Keywords); return ok && k != nil }},
+		{"keywords/1925453", func(v interface{}) bool { k, ok := v.(*Keywords); return ok && k != nil }},
+		{"external-identifiers", func(v interface{}) bool { e, ok := v.(*ExternalIdentifiers); return ok && e != nil }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.segment, func(t *testing.T) {
+			result, err := client.GetByPath(ctx, Path("/0000-0002-1825-0097/"+tt.segment))
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !tt.check(result) {
+				t.Errorf("Unexpected result %T (%v) for %s", result, result, tt.segment)
+			}
+		})
+	}
+
+	if _, err := client.GetByPath(ctx, "/0000-0002-1825-0097/unknown"); err == nil || !strings.Contains(err.Error(), "unsupported resource type") {
+		t.Errorf("Expected unsupported resource type error, got %v", err)
+	}
+}
+
+func TestGetRecords(t *testing.T) {
+	var active, peak atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		n := active.Add(1)
+		defer active.Add(-1)
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		orcidID := strings.Split(strings.TrimPrefix(r.URL.Path, "/v3.0/"), "/")[0]
+		if orcidID == "0000-0001-5109-3700" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"orcid-identifier": {"path": %q}}`, orcidID)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(1000),
+	)
+
+	ids := []string{"0000-0002-1825-0097", "0000-0001-5109-3700", "0000-0002-1694-233X", "0000-0002-1825-0097", "0000-0003-1419-2405"}
+	records, errs := client.GetRecords(context.Background(), ids, 2)
+
+	if len(records) != 3 {
+		t.Errorf("Expected 3 records, got %d", len(records))
+	}
+	for _, id := range []string{"0000-0002-1825-0097", "0000-0002-1694-233X", "0000-0003-1419-2405"} {
+		if record := records[id]; record == nil || string(record.OrcidIdentifier.Path) != id {
+			t.Errorf("Expected record for %s, got %+v", id, record)
+		}
+	}
+	if len(errs) != 1 || !errors.Is(errs["0000-0001-5109-3700"], ErrNotFound) {
+		t.Errorf("Expected only a not found error for 0000-0001-5109-3700, got %v", errs)
+	}
+	if p := peak.Load(); p > 2 {
+		t.Errorf("Expected at most 2 concurrent requests, got %d", p)
+	}
+}
+
+func TestGetRecordsAbortsOnUnauthorized(t *testing.T) {
+	var requests atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests.Add(1)
+		w.WriteHeader(http.StatusUnauthorized)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(1000),
+	)
+
+	ids := []string{"0000-0002-1825-0097", "0000-0001-5109-3700", "0000-0002-1694-233X", "0000-0003-1419-2405"}
+	records, errs := client.GetRecords(context.Background(), ids, 1)
+
+	if len(records) != 0 {
+		t.Errorf("Expected no records, got %d", len(records))
+	}
+	for _, id := range ids {
+		if !errors.Is(errs[id], ErrUnauthorized) {
+			t.Errorf("Expected ErrUnauthorized for %s, got %v", id, errs[id])
+		}
+	}
+	if n := requests.Load(); n != 1 {
+		t.Errorf("Expected the batch to stop after 1 request, got %d", n)
+	}
+}
+
+func TestGetRecordsContextDeadline(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(2 * time.Second):
+		}
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(1000),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+
+	ids := []string{"0000-0002-1825-0097", "0000-0001-5109-3700", "0000-0002-1694-233X"}
+	start := time.Now()
+	records, errs := client.GetRecords(ctx, ids, 1)
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected GetRecords to return promptly after the deadline, took %v", elapsed)
+	}
+	if len(records) != 0 {
+		t.Errorf("Expected no records, got %d", len(records))
+	}
+	for _, id := range ids {
+		if !errors.Is(errs[id], context.DeadlineExceeded) {
+			t.Errorf("Expected deadline exceeded for %s, got %v", id, errs[id])
+		}
+	}
+}
+
+func TestDecodeResponseStreams(t *testing.T) {
+	for _, contentType := range []ContentType{ContentTypeJSON, ContentTypeXML} {
+		t.Run(string(contentType), func(t *testing.T) {
+			client := NewClient(WithContentType(contentType))
+			defer client.Close()
+
+			body := `{"orcid-identifier": {"path": "0000-0002-1825-0097"}}`
+			if contentType == ContentTypeXML {
+				body = `<record><orcid-identifier><path>0000-0002-1825-0097</path></orcid-identifier></record>`
+			}
+
+			// The writer never closes the pipe, so decoding only finishes if
+			// it does not wait for the end of the body
+			r, w := io.Pipe()
+			defer r.Close()
+			go w.Write([]byte(body))
+
+			done := make(chan error, 1)
+			var record Record
+			go func() { done <- client.decodeResponse(r, &record) }()
+
+			select {
+			case err := <-done:
+				if err != nil {
+					t.Fatalf("Unexpected error: %v", err)
+				}
+			case <-time.After(time.Second):
+				t.Fatal("Expected decoding to finish without reading to EOF")
+			}
+			if record.OrcidIdentifier == nil || record.OrcidIdentifier.Path != "0000-0002-1825-0097" {
+				t.Errorf("Expected decoded record, got %+v", record.OrcidIdentifier)
+			}
+		})
+	}
+}
+
+func TestCompression(t *testing.T) {
+	const body = `{"orcid-identifier": {"path": "0000-0002-1825-0097"}}`
+	var acceptEncoding string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		acceptEncoding = r.Header.Get("Accept-Encoding")
+		w.Header().Set("Content-Type", "application/json")
+		if !strings.Contains(acceptEncoding, "gzip") {
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(body))
+			return
+		}
+		w.Header().Set("Content-Encoding", "gzip")
+		w.WriteHeader(http.StatusOK)
+		zw := gzip.NewWriter(w)
+		zw.Write([]byte(body))
+		zw.Close()
+	}))
+	defer server.Close()
+
+	tests := []struct {
+		name           string
+		opts           []ClientOption
+		acceptEncoding string
+	}{
+		{"default", nil, "gzip"},
+		{"enabled", []ClientOption{WithCompression(true)}, "gzip"},
+		{"disabled", []ClientOption{WithCompression(false)}, "identity"},
+		{"custom transport", []ClientOption{WithHTTPClient(&http.Client{Transport: &http.Transport{DisableCompression: true}})}, "gzip"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			opts := append([]ClientOption{
+				WithAPIURL(server.URL + "/v3.0"),
+				WithBearerToken("test-token"),
+			}, tt.opts...)
+			client := NewClient(opts...)
+			defer client.Close()
+
+			data, err := client.GetRecordRaw(context.Background(), "0000-0002-1825-0097")
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(data) != body {
+				t.Errorf("Expected decoded body %s, got %q", body, data)
+			}
+			if acceptEncoding != tt.acceptEncoding {
+				t.Errorf("Expected Accept-Encoding %s, got %s", tt.acceptEncoding, acceptEncoding)
+			}
+		})
+	}
+}
+
+func TestWithBackoff(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	var attempts []int
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(1000),
+		WithMaxRetries(3),
+		WithBackoff(func(attempt int) time.Duration {
+			attempts = append(attempts, attempt)
+			return time.Millisecond
+		}),
+	)
+
+	start := time.Now()
+	_, err := client.GetRecord(context.Background(), "0000-0002-1825-0097")
+	if err == nil || !strings.Contains(err.Error(), "max retries exceeded") {
+		t.Fatalf("Expected max retries error, got %v", err)
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected the custom backoff to be used, took %v", elapsed)
+	}
+	if requests != 4 {
+		t.Errorf("Expected 4 requests, got %d", requests)
+	}
+	if fmt.Sprint(attempts) != "[1 2 3]" {
+		t.Errorf("Expected backoff for attempts [1 2 3], got %v", attempts)
+	}
+}
+
+func TestExponentialJitterBackoff(t *testing.T) {
+	backoff := ExponentialJitterBackoff(100*time.Millisecond, time.Second)
+	tests := []struct {
+		attempt int
+		ceiling time.Duration
+	}{
+		{1, 100 * time.Millisecond},
+		{2, 200 * time.Millisecond},
+		{3, 400 * time.Millisecond},
+		{4, 800 * time.Millisecond},
+		{5, time.Second},
+		{100, time.Second},
+	}
+
+	for _, tt := range tests {
+		for i := 0; i < 100; i++ {
+			d := backoff(tt.attempt)
+			if d < tt.ceiling/2 || d > tt.ceiling {
+				t.Fatalf("Expected backoff for attempt %d between %v and %v, got %v", tt.attempt, tt.ceiling/2, tt.ceiling, d)
+			}
+		}
+	}
 }
@@ -0,0 +1,205 @@
+package orcid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
+
+// DefaultCrossRefURL is the CrossRef REST API used by EnrichWorkFromCrossRef.
+const DefaultCrossRefURL = "https://api.crossref.org"
+
+// WithCrossRefURL sets the CrossRef REST API URL used by
+// EnrichWorkFromCrossRef, for example to point it at a test server.
+func WithCrossRefURL(url string) ClientOption {
+	return func(c *Client) {
+		c.crossRefURL = strings.TrimRight(url, "/")
+	}
+}
+
+// crossRefTypes maps CrossRef work types to their ORCID equivalents.
+var crossRefTypes = map[string]WorkType{
+	"book":                WorkTypeBook,
+	"book-chapter":        WorkTypeBookChapter,
+	"dataset":             WorkTypeDataSet,
+	"dissertation":        WorkTypeDissertationThesis,
+	"edited-book":         WorkTypeEditedBook,
+	"journal-article":     WorkTypeJournalArticle,
+	"journal-issue":       WorkTypeJournalIssue,
+	"monograph":           WorkTypeBook,
+	"peer-review":         WorkTypeReview,
+	"posted-content":      WorkTypePreprint,
+	"proceedings-article": WorkTypeConferencePaper,
+	"reference-entry":     WorkTypeEncyclopediaEntry,
+	"report":              WorkTypeReport,
+	"standard":            WorkTypeStandardsAndPolicy,
+}
+
+// crossRefWork is the part of a CrossRef /works/{doi} message used to fill
+// in a Work.
+type crossRefWork struct {
+	Title          []string `json:"title"`
+	Subtitle       []string `json:"subtitle"`
+	ContainerTitle []string `json:"container-title"`
+	Type           string   `json:"type"`
+	URL            string   `json:"URL"`
+	Language       string   `json:"language"`
+	Issued         struct {
+		DateParts [][]int `json:"date-parts"`
+	} `json:"issued"`
+	Author []struct {
+		Given    string `json:"given"`
+		Family   string `json:"family"`
+		Name     string `json:"name"`
+		ORCID    string `json:"ORCID"`
+		Sequence string `json:"sequence"`
+	} `json:"author"`
+}
+
+// EnrichWorkFromCrossRef looks up the DOI of summary in CrossRef and returns
+// a full Work combining both: fields set on the summary are kept, and the
+// title, journal, publication date, type and URL are filled from CrossRef
+// when missing. Contributors, which summaries never carry, come from the
+// CrossRef authors.
+//
+// The request uses the client's HTTP client, user agent and ctx, including a
+// timeout set with RequestTimeout, but not its ORCID token or rate limit. A
+// DOI CrossRef does not know yields an error matching ErrNotFound.
+func (c *Client) EnrichWorkFromCrossRef(ctx context.Context, summary *WorkSummary) (*Work, error) {
+	if summary == nil {
+		return nil, fmt.Errorf("work is nil")
+	}
+	doi, ok := summary.DOI()
+	if !ok || doi == "" {
+		return nil, fmt.Errorf("work %d has no DOI", summary.PutCode)
+	}
+
+	baseURL := c.crossRefURL
+	if baseURL == "" {
+		baseURL = DefaultCrossRefURL
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, baseURL+"/works/"+url.PathEscape(doi), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.userAgent != "" {
+		req.Header.Set("User-Agent", c.userAgent)
+	}
+
+	resp, err := c.httpClientFor(ctx).Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	switch {
+	case resp.StatusCode == http.StatusNotFound:
+		return nil, fmt.Errorf("DOI %s not in CrossRef: %w", doi, ErrNotFound)
+	case resp.StatusCode != http.StatusOK:
+		data, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, fmt.Errorf("crossref request failed: HTTP %d: %s", resp.StatusCode, strings.TrimSpace(string(data)))
+	}
+
+	var body struct {
+		Message crossRefWork `json:"message"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("decoding crossref response: %w", err)
+	}
+	return mergeCrossRefWork(summary, &body.Message), nil
+}
+
+func mergeCrossRefWork(summary *WorkSummary, message *crossRefWork) *Work {
+	work := &Work{
+		PutCode:          summary.PutCode,
+		CreatedDate:      summary.CreatedDate,
+		LastModifiedDate: summary.LastModifiedDate,
+		Source:           summary.Source,
+		Title:            summary.Title,
+		JournalTitle:     summary.JournalTitle,
+		Type:             summary.Type,
+		PublicationDate:  summary.PublicationDate,
+		ExternalIDs:      summary.ExternalIDs,
+		Visibility:       summary.Visibility,
+		Path:             summary.Path,
+	}
+
+	if (work.Title == nil || work.Title.Title == nil) && len(message.Title) > 0 {
+		work.Title = &Title{Title: &TitleValue{Value: message.Title[0]}}
+		if len(message.Subtitle) > 0 {
+			work.Title.Subtitle = &Subtitle{Value: message.Subtitle[0]}
+		}
+	}
+	if work.JournalTitle.Value == "" && len(message.ContainerTitle) > 0 {
+		work.JournalTitle.Value = message.ContainerTitle[0]
+	}
+	if work.Type == "" {
+		if workType, ok := crossRefTypes[message.Type]; ok {
+			work.Type = workType
+		} else if message.Type != "" {
+			work.Type = WorkTypeOther
+		}
+	}
+	if work.PublicationDate == nil && len(message.Issued.DateParts) > 0 {
+		work.PublicationDate = crossRefDate(message.Issued.DateParts[0])
+	}
+	if message.URL != "" {
+		work.URL = &URL{Value: message.URL}
+	}
+	work.LanguageCode = message.Language
+
+	var contributors []*Contributor
+	for _, author := range message.Author {
+		name := strings.TrimSpace(author.Given + " " + author.Family)
+		if name == "" {
+			name = strings.TrimSpace(author.Name)
+		}
+		if name == "" {
+			continue
+		}
+		sequence := "additional"
+		if author.Sequence == "first" {
+			sequence = "first"
+		}
+		contributor := &Contributor{
+			CreditName: &CreditName{Value: name},
+			ContributorAttributes: &ContributorAttributes{
+				ContributorSequence: sequence,
+				ContributorRole:     "author",
+			},
+		}
+		if id, err := ResolveOrcidID(author.ORCID); err == nil {
+			contributor.ContributorOrcid = &ContributorOrcid{
+				URI:  CanonicalURL(id, false),
+				Path: Path(id),
+				Host: "orcid.org",
+			}
+		}
+		contributors = append(contributors, contributor)
+	}
+	if len(contributors) > 0 {
+		work.Contributors = &Contributors{Contributor: contributors}
+	}
+	return work
+}
+
+// crossRefDate converts CrossRef date parts, [year, month, day] with the
+// later parts optional, to an ORCID publication date.
+func crossRefDate(parts []int) *PublicationDate {
+	if len(parts) == 0 || parts[0] <= 0 {
+		return nil
+	}
+	date := &PublicationDate{Year: &Year{Value: fmt.Sprintf("%04d", parts[0])}}
+	if len(parts) > 1 && parts[1] > 0 {
+		date.Month = &Month{Value: fmt.Sprintf("%02d", parts[1])}
+		if len(parts) > 2 && parts[2] > 0 {
+			date.Day = &Day{Value: fmt.Sprintf("%02d", parts[2])}
+		}
+	}
+	return date
+}
@@ -0,0 +1,119 @@
+package orcid
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestEnrichWorkFromCrossRef(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.EscapedPath() != "/works/10.1000%2Ftest" {
+			t.Errorf("Expected path /works/10.1000%%2Ftest, got %s", r.URL.EscapedPath())
+		}
+		if got := r.Header.Get("User-Agent"); got != "TestApp/1.0" {
+			t.Errorf("Expected User-Agent TestApp/1.0, got %q", got)
+		}
+		if r.Header.Get("Authorization") != "" {
+			t.Error("Expected no ORCID token to be sent to CrossRef")
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"status": "ok", "message": {
+			"title": ["Test Publication"],
+			"container-title": ["Journal of Tests"],
+			"type": "proceedings-article",
+			"URL": "https://doi.org/10.1000/test",
+			"issued": {"date-parts": [[2021, 3, 7]]},
+			"author": [
+				{"given": "Josiah", "family": "Carberry", "sequence": "first", "ORCID": "http://orcid.org/0000-0002-1825-0097"},
+				{"name": "Test Consortium", "sequence": "additional"}
+			]
+		}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithCrossRefURL(server.URL+"/"), WithUserAgent("TestApp/1.0"), WithBearerToken("token"))
+	defer client.Close()
+
+	summary := &WorkSummary{
+		PutCode: 12345,
+		Title:   &Title{Title: &TitleValue{Value: "Title in ORCID"}},
+		ExternalIDs: &ExternalIDs{ExternalID: []*ExternalID{
+			{ExternalIDType: "doi", ExternalIDValue: "https://doi.org/10.1000/TEST"},
+		}},
+	}
+	work, err := client.EnrichWorkFromCrossRef(context.Background(), summary)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if work.PutCode != 12345 || work.ExternalIDs != summary.ExternalIDs {
+		t.Error("Expected the summary's put-code and external IDs to be kept")
+	}
+	if work.Title.Title.Value != "Title in ORCID" {
+		t.Errorf("Expected the summary's title to be kept, got %q", work.Title.Title.Value)
+	}
+	if work.JournalTitle.Value != "Journal of Tests" {
+		t.Errorf("Expected journal title from CrossRef, got %q", work.JournalTitle.Value)
+	}
+	if work.Type != WorkTypeConferencePaper {
+		t.Errorf("Expected type %s, got %s", WorkTypeConferencePaper, work.Type)
+	}
+	if d := work.PublicationDate; d == nil || d.Year.Value != "2021" || d.Month.Value != "03" || d.Day.Value != "07" {
+		t.Errorf("Expected publication date 2021-03-07, got %+v", d)
+	}
+	if work.URL == nil || work.URL.Value != "https://doi.org/10.1000/test" {
+		t.Errorf("Expected URL from CrossRef, got %+v", work.URL)
+	}
+
+	if work.Contributors == nil || len(work.Contributors.Contributor) != 2 {
+		t.Fatalf("Expected 2 contributors, got %+v", work.Contributors)
+	}
+	first := work.Contributors.Contributor[0]
+	if first.CreditName.Value != "Josiah Carberry" || first.ORCIDID() != "0000-0002-1825-0097" {
+		t.Errorf("Expected Josiah Carberry with ORCID iD, got %q %q", first.CreditName.Value, first.ORCIDID())
+	}
+	if first.ContributorAttributes.ContributorSequence != "first" {
+		t.Errorf("Expected first author sequence, got %q", first.ContributorAttributes.ContributorSequence)
+	}
+	second := work.Contributors.Contributor[1]
+	if second.CreditName.Value != "Test Consortium" || second.ContributorOrcid != nil {
+		t.Errorf("Expected Test Consortium without ORCID iD, got %+v", second)
+	}
+}
+
+func TestEnrichWorkFromCrossRefErrors(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/works/10.1000/slow" {
+			time.Sleep(200 * time.Millisecond)
+		}
+		http.Error(w, "Resource not found.", http.StatusNotFound)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithCrossRefURL(server.URL))
+	defer client.Close()
+	ctx := context.Background()
+
+	if _, err := client.EnrichWorkFromCrossRef(ctx, &WorkSummary{}); err == nil {
+		t.Error("Expected an error for a work without a DOI")
+	}
+
+	withDOI := func(doi string) *WorkSummary {
+		return &WorkSummary{ExternalIDs: &ExternalIDs{ExternalID: []*ExternalID{
+			{ExternalIDType: "doi", ExternalIDValue: doi},
+		}}}
+	}
+	_, err := client.EnrichWorkFromCrossRef(ctx, withDOI("10.1000/missing"))
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	timeoutCtx := WithRequestOptions(ctx, RequestTimeout(50*time.Millisecond))
+	if _, err := client.EnrichWorkFromCrossRef(timeoutCtx, withDOI("10.1000/slow")); err == nil {
+		t.Error("Expected the request timeout to apply")
+	}
+}
@@ -0,0 +1,125 @@
+package orcid
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// DatePrecision is how much of a FuzzyDate is known.
+type DatePrecision int
+
+const (
+	PrecisionNone DatePrecision = iota
+	PrecisionYear
+	PrecisionMonth
+	PrecisionDay
+)
+
+func (p DatePrecision) String() string {
+	switch p {
+	case PrecisionYear:
+		return "year"
+	case PrecisionMonth:
+		return "month"
+	case PrecisionDay:
+		return "day"
+	}
+	return "none"
+}
+
+// Precision reports which components of the date are set. A day without a
+// month is ignored, as it is by Time.
+func (f *FuzzyDate) Precision() DatePrecision {
+	switch {
+	case f == nil || f.Year == nil || strings.TrimSpace(f.Year.Value) == "":
+		return PrecisionNone
+	case f.Month == nil || strings.TrimSpace(f.Month.Value) == "":
+		return PrecisionYear
+	case f.Day == nil || strings.TrimSpace(f.Day.Value) == "":
+		return PrecisionMonth
+	}
+	return PrecisionDay
+}
+
+// Time returns the date as midnight UTC on its first possible day: a missing
+// month defaults to January and a missing day to the 1st. Use Precision to
+// tell which components were actually given. An error is returned if the
+// year is missing or any present component is out of range.
+func (f *FuzzyDate) Time() (time.Time, error) {
+	precision := f.Precision()
+	if precision == PrecisionNone {
+		return time.Time{}, fmt.Errorf("fuzzy date has no year")
+	}
+
+	year, err := strconv.Atoi(strings.TrimSpace(f.Year.Value))
+	if err != nil || year < 1 || year > 9999 {
+		return time.Time{}, fmt.Errorf("invalid year %q", f.Year.Value)
+	}
+
+	month, day := 1, 1
+	if precision >= PrecisionMonth {
+		month, err = strconv.Atoi(strings.TrimSpace(f.Month.Value))
+		if err != nil || month < 1 || month > 12 {
+			return time.Time{}, fmt.Errorf("invalid month %q", f.Month.Value)
+		}
+	}
+	if precision == PrecisionDay {
+		day, err = strconv.Atoi(strings.TrimSpace(f.Day.Value))
+		if err != nil || day < 1 || day > daysIn(year, time.Month(month)) {
+			return time.Time{}, fmt.Errorf("invalid day %q for %04d-%02d", f.Day.Value, year, month)
+		}
+	}
+
+	return time.Date(year, time.Month(month), day, 0, 0, 0, 0, time.UTC), nil
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+// unmarshalDateComponent decodes the value of a year, month or day, which
+// ORCID normally sends as a string but some data sources, such as the public
+// data dumps, send as a number. Numbers are normalized to ORCID's string form,
+// zero-padded to width digits ("2021", "03").
+func unmarshalDateComponent(data []byte, value *string, width int) error {
+	var raw struct {
+		Value json.RawMessage `json:"value"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	switch v := bytes.TrimSpace(raw.Value); {
+	case len(v) == 0 || bytes.Equal(v, []byte("null")):
+		*value = ""
+	case v[0] == '"':
+		return json.Unmarshal(v, value)
+	default:
+		var n json.Number
+		if err := json.Unmarshal(v, &n); err != nil {
+			return fmt.Errorf("date value must be a string or number: %s", v)
+		}
+		f, err := n.Float64()
+		if err != nil || f != float64(int64(f)) || f < 0 {
+			return fmt.Errorf("date value must be a non-negative integer: %s", v)
+		}
+		*value = fmt.Sprintf("%0*d", width, int64(f))
+	}
+	return nil
+}
+
+func (y *Year) UnmarshalJSON(data []byte) error {
+	return unmarshalDateComponent(data, &y.Value, 4)
+}
+
+func (m *Month) UnmarshalJSON(data []byte) error {
+	return unmarshalDateComponent(data, &m.Value, 2)
+}
+
+func (d *Day) UnmarshalJSON(data []byte) error {
+	return unmarshalDateComponent(data, &d.Value, 2)
+}
@@ -0,0 +1,172 @@
+package orcid
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestFuzzyDateTime(t *testing.T) {
+	tests := []struct {
+		name      string
+		json      string
+		expected  time.Time
+		precision DatePrecision
+		wantErr   bool
+	}{
+		{
+			name:      "Full date",
+			json:      `{"year": {"value": "2021"}, "month": {"value": "03"}, "day": {"value": "15"}}`,
+			expected:  time.Date(2021, 3, 15, 0, 0, 0, 0, time.UTC),
+			precision: PrecisionDay,
+		},
+		{
+			name:      "Year and month",
+			json:      `{"year": {"value": "2021"}, "month": {"value": "11"}}`,
+			expected:  time.Date(2021, 11, 1, 0, 0, 0, 0, time.UTC),
+			precision: PrecisionMonth,
+		},
+		{
+			name:      "Year only",
+			json:      `{"year": {"value": "2021"}}`,
+			expected:  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			precision: PrecisionYear,
+		},
+		{
+			name:      "Numeric year",
+			json:      `{"year": {"value": 2021}, "month": {"value": 2}}`,
+			expected:  time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC),
+			precision: PrecisionMonth,
+		},
+		{
+			name:      "Day without month",
+			json:      `{"year": {"value": "2021"}, "day": {"value": "15"}}`,
+			expected:  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			precision: PrecisionYear,
+		},
+		{
+			name:    "No year",
+			json:    `{"month": {"value": "03"}}`,
+			wantErr: true,
+		},
+		{
+			name:      "Invalid day",
+			json:      `{"year": {"value": "2021"}, "month": {"value": "02"}, "day": {"value": "30"}}`,
+			precision: PrecisionDay,
+			wantErr:   true,
+		},
+		{
+			name:      "Invalid month",
+			json:      `{"year": {"value": "2021"}, "month": {"value": "13"}}`,
+			precision: PrecisionMonth,
+			wantErr:   true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var date FuzzyDate
+			if err := json.Unmarshal([]byte(tt.json), &date); err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if precision := date.Precision(); precision != tt.precision {
+				t.Errorf("Expected precision %s, got %s", tt.precision, precision)
+			}
+
+			got, err := date.Time()
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got %v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if !got.Equal(tt.expected) {
+				t.Errorf("Expected %v, got %v", tt.expected, got)
+			}
+		})
+	}
+
+	var nilDate *FuzzyDate
+	if _, err := nilDate.Time(); err == nil {
+		t.Error("Expected error for nil date")
+	}
+}
+
+func TestDateComponentUnmarshalJSON(t *testing.T) {
+	tests := []struct {
+		name     string
+		json     string
+		expected FuzzyDate
+		wantErr  bool
+	}{
+		{
+			name:     "String values",
+			json:     `{"year": {"value": "2021"}, "month": {"value": "03"}, "day": {"value": "07"}}`,
+			expected: FuzzyDate{Year: &Year{Value: "2021"}, Month: &Month{Value: "03"}, Day: &Day{Value: "07"}},
+		},
+		{
+			name:     "Numeric values",
+			json:     `{"year": {"value": 2021}, "month": {"value": 3}, "day": {"value": 7}}`,
+			expected: FuzzyDate{Year: &Year{Value: "2021"}, Month: &Month{Value: "03"}, Day: &Day{Value: "07"}},
+		},
+		{
+			name:     "Mixed values",
+			json:     `{"year": {"value": 2021.0}, "month": {"value": "12"}, "day": {"value": 31}}`,
+			expected: FuzzyDate{Year: &Year{Value: "2021"}, Month: &Month{Value: "12"}, Day: &Day{Value: "31"}},
+		},
+		{
+			name:     "Null value",
+			json:     `{"year": {"value": 2021}, "month": {"value": null}}`,
+			expected: FuzzyDate{Year: &Year{Value: "2021"}, Month: &Month{}},
+		},
+		{
+			name:    "Fractional value",
+			json:    `{"year": {"value": 2021.5}}`,
+			wantErr: true,
+		},
+		{
+			name:    "Boolean value",
+			json:    `{"year": {"value": true}}`,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var date FuzzyDate
+			err := json.Unmarshal([]byte(tt.json), &date)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got %+v", date)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+
+			if date.Year.Value != tt.expected.Year.Value {
+				t.Errorf("Expected year %q, got %q", tt.expected.Year.Value, date.Year.Value)
+			}
+			if (date.Month == nil) != (tt.expected.Month == nil) || (date.Month != nil && date.Month.Value != tt.expected.Month.Value) {
+				t.Errorf("Expected month %+v, got %+v", tt.expected.Month, date.Month)
+			}
+			if (date.Day == nil) != (tt.expected.Day == nil) || (date.Day != nil && date.Day.Value != tt.expected.Day.Value) {
+				t.Errorf("Expected day %+v, got %+v", tt.expected.Day, date.Day)
+			}
+
+			// Normalized values marshal back to ORCID's string form
+			data, err := json.Marshal(date.Year)
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if string(data) != `{"value":"`+tt.expected.Year.Value+`"}` {
+				t.Errorf("Expected year to marshal as a string, got %s", data)
+			}
+		})
+	}
+}
@@ -0,0 +1,224 @@
+package orcid
+
+import (
+	"bytes"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+)
+
+// ErrNotFound matches, through errors.Is, the error returned when ORCID
+// responds with HTTP 404, for example for a put-code that does not exist or
+// was already deleted.
+var ErrNotFound = errors.New("not found")
+
+// ErrUnauthorized matches errors for HTTP 401 and 403 responses, meaning the
+// token is missing, expired or lacks the scope the request needs. Unlike
+// ErrNotFound it usually affects every following request too.
+var ErrUnauthorized = errors.New("unauthorized")
+
+// ErrRateLimited matches errors for HTTP 429 responses that were still being
+// rate limited after the client's retries ran out.
+var ErrRateLimited = errors.New("rate limited")
+
+// APIError is returned when ORCID answers a request with an unsuccessful
+// status. When the response carries ORCID's error document, its error code
+// and developer message are parsed out; Raw always holds the body as sent.
+//
+//	var apiErr *orcid.APIError
+//	if errors.As(err, &apiErr) && apiErr.StatusCode == http.StatusConflict {
+//		// the item already exists
+//	}
+type APIError struct {
+	StatusCode       int
+	Status           string
+	ORCIDErrorCode   int
+	DeveloperMessage string
+	// OAuthError is the OAuth error code, such as "invalid_grant", of a
+	// failed token request or revocation. DeveloperMessage then holds its
+	// error_description.
+	OAuthError string
+	Raw        []byte
+}
+
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Raw:        body,
+	}
+
+	var orcidErr OrcidError
+	trimmed := bytes.TrimSpace(body)
+	var err error
+	if len(trimmed) > 0 && trimmed[0] == '<' {
+		err = xml.Unmarshal(trimmed, &orcidErr)
+	} else {
+		err = json.Unmarshal(trimmed, &orcidErr)
+	}
+	if err == nil {
+		e.ORCIDErrorCode = orcidErr.ErrorCode
+		e.DeveloperMessage = orcidErr.DeveloperMessage
+	}
+	return e
+}
+
+// newOAuthError builds the error for an unsuccessful response from the
+// OAuth endpoints, which report errors as defined by OAuth 2.0 rather than
+// in ORCID's error document.
+func newOAuthError(resp *http.Response, body []byte) *APIError {
+	e := &APIError{
+		StatusCode: resp.StatusCode,
+		Status:     resp.Status,
+		Raw:        body,
+	}
+
+	var oauthErr struct {
+		Error            string `json:"error"`
+		ErrorDescription string `json:"error_description"`
+	}
+	if json.Unmarshal(bytes.TrimSpace(body), &oauthErr) == nil {
+		e.OAuthError = oauthErr.Error
+		e.DeveloperMessage = oauthErr.ErrorDescription
+	}
+	return e
+}
+
+func (e *APIError) Error() string {
+	if len(e.Raw) == 0 {
+		return fmt.Sprintf("HTTP %d: %s", e.StatusCode, e.Status)
+	}
+	return fmt.Sprintf("HTTP %d: %s - %s", e.StatusCode, e.Status, string(e.Raw))
+}
+
+// Is matches the status code against ErrNotFound, ErrUnauthorized and
+// ErrRateLimited. OAuth errors for rejected client credentials or an invalid
+// grant, such as a revoked refresh token, match ErrUnauthorized even though
+// OAuth reports the latter with HTTP 400.
+func (e *APIError) Is(target error) bool {
+	switch target {
+	case ErrNotFound:
+		return e.StatusCode == http.StatusNotFound
+	case ErrUnauthorized:
+		switch e.OAuthError {
+		case "invalid_client", "invalid_grant", "unauthorized_client":
+			return true
+		}
+		return e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden
+	case ErrRateLimited:
+		return e.StatusCode == http.StatusTooManyRequests
+	}
+	return false
+}
+
+// ErrDeprecatedRecord is returned when the requested ORCID iD has been
+// deprecated and merged into another record. ORCID answers such requests
+// with a redirect; PrimaryID is the iD the record now lives under, taken from
+// the Location header or, failing that, from the response body. Fetching
+// PrimaryID instead returns the merged record, which is what
+// GetRecordResolved does.
+type ErrDeprecatedRecord struct {
+	ID         string
+	PrimaryID  string
+	StatusCode int
+	Location   string
+}
+
+func (e *ErrDeprecatedRecord) Error() string {
+	return fmt.Sprintf("ORCID record %s is deprecated, primary record is %s", e.ID, e.PrimaryID)
+}
+
+// newRedirectError builds the error for a redirect that checkRedirect did not
+// follow, recognising ORCID's deprecation responses.
+func newRedirectError(resp *http.Response, requestURL string, body []byte) error {
+	location := resp.Header.Get("Location")
+	requestedID := orcidIDFromPath(requestURL)
+
+	primaryID := orcidIDFromPath(location)
+	if primaryID == "" || primaryID == requestedID {
+		primaryID = orcidIDFromText(string(body), requestedID)
+	}
+	if primaryID != "" && primaryID != requestedID {
+		return &ErrDeprecatedRecord{
+			ID:         requestedID,
+			PrimaryID:  primaryID,
+			StatusCode: resp.StatusCode,
+			Location:   location,
+		}
+	}
+
+	return &redirectError{
+		statusCode: resp.StatusCode,
+		status:     resp.Status,
+		location:   location,
+	}
+}
+
+// orcidIDFromText returns the first formatted ORCID iD in text other than
+// exclude, such as the primary iD in a deprecation message.
+func orcidIDFromText(text, exclude string) string {
+	fields := strings.FieldsFunc(text, func(r rune) bool {
+		return !(r >= '0' && r <= '9' || r == 'X' || r == '-')
+	})
+	for _, field := range fields {
+		if ValidateOrcidID(field) != nil {
+			continue
+		}
+		if id := FormatOrcidID(field); id != exclude {
+			return id
+		}
+	}
+	return ""
+}
+
+// redirectError is returned for redirects that were not followed because
+// they point at a different ORCID iD that could not be identified.
+type redirectError struct {
+	statusCode int
+	status     string
+	location   string
+}
+
+func (e *redirectError) Error() string {
+	return fmt.Sprintf("HTTP %d: %s - redirected to %s", e.statusCode, e.status, e.location)
+}
+
+// OrcidValidationReason says why ValidateOrcidID rejected an iD.
+type OrcidValidationReason int
+
+const (
+	// LengthError means the iD does not have 16 digits once hyphens are
+	// removed, as when only part of it was copied.
+	LengthError OrcidValidationReason = iota + 1
+	// CharError means the iD contains something other than digits, or an X
+	// as the check digit.
+	CharError
+	// ChecksumError means the check digit does not match, usually because of
+	// a mistyped digit.
+	ChecksumError
+)
+
+// OrcidValidationError is returned by ValidateOrcidID. Length is the number
+// of characters without hyphens, and Position the index of the offending
+// character for CharError.
+type OrcidValidationError struct {
+	Reason   OrcidValidationReason
+	Length   int
+	Position int
+}
+
+func (e *OrcidValidationError) Error() string {
+	switch {
+	case e.Reason == LengthError:
+		return fmt.Sprintf("invalid ORCID iD length: %d", e.Length)
+	case e.Reason == CharError && e.Position == 15:
+		return "invalid check digit in ORCID iD"
+	case e.Reason == CharError:
+		return fmt.Sprintf("invalid character in ORCID iD at position %d", e.Position)
+	default:
+		return "invalid ORCID iD checksum"
+	}
+}
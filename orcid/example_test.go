@@ -230,3 +230,43 @@ func ExampleClient_GetEmployments() {
 		}
 	}
 }
+
+// fakeORCID implements orcid.API for tests. Embedding the interface means
+// only the methods the code under test calls need to be written.
+type fakeORCID struct {
+	orcid.API
+	records map[string]*orcid.Record
+}
+
+func (f *fakeORCID) GetRecord(ctx context.Context, orcidID string) (*orcid.Record, error) {
+	if record, ok := f.records[orcidID]; ok {
+		return record, nil
+	}
+	return nil, orcid.ErrNotFound
+}
+
+// familyName is code under test that only needs orcid.API.
+func familyName(ctx context.Context, api orcid.API, orcidID string) (string, error) {
+	record, err := api.GetRecord(ctx, orcidID)
+	if err != nil {
+		return "", err
+	}
+	return record.Person.Name.FamilyName.Value, nil
+}
+
+func ExampleAPI() {
+	fake := &fakeORCID{records: map[string]*orcid.Record{
+		"0000-0002-1825-0097": {
+			Person: &orcid.Person{Name: &orcid.Name{FamilyName: &orcid.FamilyName{Value: "Carberry"}}},
+		},
+	}}
+
+	name, err := familyName(context.Background(), fake, "0000-0002-1825-0097")
+	fmt.Println(name, err)
+
+	_, err = familyName(context.Background(), fake, "0000-0001-5109-3700")
+	fmt.Println(err)
+	// Output:
+	// Carberry <nil>
+	// not found
+}
@@ -0,0 +1,457 @@
+package orcid
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// risTypes maps ORCID work types to RIS reference types. Other types are
+// exported as GEN.
+var risTypes = map[WorkType]string{
+	WorkTypeBook:               "BOOK",
+	WorkTypeBookChapter:        "CHAP",
+	WorkTypeConferenceAbstract: "ABST",
+	WorkTypeConferencePaper:    "CPAPER",
+	WorkTypeConferencePoster:   "CPAPER",
+	WorkTypeDataSet:            "DATA",
+	WorkTypeDissertationThesis: "THES",
+	WorkTypeEditedBook:         "EDBOOK",
+	WorkTypeEncyclopediaEntry:  "ENCYC",
+	WorkTypeJournalArticle:     "JOUR",
+	WorkTypeMagazineArticle:    "MGZN",
+	WorkTypeNewspaperArticle:   "NEWS",
+	WorkTypePatent:             "PAT",
+	WorkTypePreprint:           "UNPB",
+	WorkTypeReport:             "RPRT",
+	WorkTypeSoftware:           "COMP",
+	WorkTypeWebsite:            "ELEC",
+	WorkTypeWorkingPaper:       "UNPB",
+}
+
+// WorksToRIS renders the preferred summary of every work as an RIS record,
+// so a work added by several sources appears once. Work summaries do not
+// carry contributors, so the records have no AU lines; use WorkToRIS on full
+// works when authors are needed.
+func WorksToRIS(works *Works) (string, error) {
+	if works == nil {
+		return "", fmt.Errorf("works is nil")
+	}
+
+	var b strings.Builder
+	for _, summary := range works.PreferredSummaries() {
+		writeRISRecord(&b, summary.Type, summary.Title, summary.JournalTitle.Value,
+			summary.PublicationDate, summary.ExternalIDs, nil)
+	}
+	return b.String(), nil
+}
+
+// WorkToRIS renders a full work, including its contributors, as an RIS record.
+func WorkToRIS(work *Work) (string, error) {
+	if work == nil {
+		return "", fmt.Errorf("work is nil")
+	}
+
+	var b strings.Builder
+	writeRISRecord(&b, work.Type, work.Title, work.JournalTitle.Value,
+		work.PublicationDate, work.ExternalIDs, work.Contributors)
+	return b.String(), nil
+}
+
+func writeRISRecord(b *strings.Builder, workType WorkType, title *Title, journal string, date *PublicationDate, ids *ExternalIDs, contributors *Contributors) {
+	risType, ok := risTypes[workType]
+	if !ok {
+		risType = "GEN"
+	}
+	writeRISLine(b, "TY", risType)
+
+	if title != nil && title.Title != nil {
+		writeRISLine(b, "TI", title.Title.Value)
+	}
+	if contributors != nil {
+		for _, contributor := range contributors.Contributor {
+			if contributor != nil && contributor.CreditName != nil {
+				writeRISLine(b, "AU", contributor.CreditName.Value)
+			}
+		}
+	}
+	writeRISLine(b, "JO", journal)
+	if date != nil && date.Year != nil {
+		writeRISLine(b, "PY", date.Year.Value)
+	}
+	doi, _ := selfDOI(ids)
+	writeRISLine(b, "DO", doi)
+
+	b.WriteString("ER  - \n\n")
+}
+
+func writeRISLine(b *strings.Builder, tag, value string) {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "" {
+		return
+	}
+	fmt.Fprintf(b, "%s  - %s\n", tag, value)
+}
+
+// bibTeXTypes maps ORCID work types to BibTeX entry types. Other types are
+// exported as misc.
+var bibTeXTypes = map[WorkType]string{
+	WorkTypeBook:               "book",
+	WorkTypeBookChapter:        "incollection",
+	WorkTypeConferencePaper:    "inproceedings",
+	WorkTypeDissertationThesis: "phdthesis",
+	WorkTypeEditedBook:         "book",
+	WorkTypeJournalArticle:     "article",
+	WorkTypeManual:             "manual",
+	WorkTypeReport:             "techreport",
+	WorkTypeWorkingPaper:       "techreport",
+}
+
+// WorksToBibTeX renders the preferred summary of every work as a BibTeX
+// entry keyed by its put-code, so a work added by several sources appears
+// once. Like WorksToRIS, the entries have no authors, which only full works
+// carry; use WorkToBibTeX for those.
+func WorksToBibTeX(works *Works) (string, error) {
+	if works == nil {
+		return "", fmt.Errorf("works is nil")
+	}
+
+	var b strings.Builder
+	for _, summary := range works.PreferredSummaries() {
+		writeBibTeXEntry(&b, summary.PutCode, summary.Type, summary.Title, summary.JournalTitle.Value,
+			summary.PublicationDate, summary.ExternalIDs, nil)
+	}
+	return b.String(), nil
+}
+
+// WorkToBibTeX renders a full work as a BibTeX entry. A BibTeX citation
+// stored on the work by its source is returned as is.
+func WorkToBibTeX(work *Work) (string, error) {
+	if work == nil {
+		return "", fmt.Errorf("work is nil")
+	}
+	if work.Citation != nil && strings.EqualFold(work.Citation.CitationType, "bibtex") &&
+		strings.TrimSpace(work.Citation.CitationValue) != "" {
+		return strings.TrimSpace(work.Citation.CitationValue) + "\n\n", nil
+	}
+
+	var b strings.Builder
+	writeBibTeXEntry(&b, work.PutCode, work.Type, work.Title, work.JournalTitle.Value,
+		work.PublicationDate, work.ExternalIDs, work.Contributors)
+	return b.String(), nil
+}
+
+func writeBibTeXEntry(b *strings.Builder, putCode int64, workType WorkType, title *Title, container string, date *PublicationDate, ids *ExternalIDs, contributors *Contributors) {
+	entryType, ok := bibTeXTypes[workType]
+	if !ok {
+		entryType = "misc"
+	}
+	fmt.Fprintf(b, "@%s{orcid%d,\n", entryType, putCode)
+
+	if title != nil && title.Title != nil {
+		writeBibTeXField(b, "title", title.Title.Value)
+	}
+	if contributors != nil {
+		var authors []string
+		for _, contributor := range contributors.Contributor {
+			if contributor != nil && contributor.CreditName != nil && strings.TrimSpace(contributor.CreditName.Value) != "" {
+				authors = append(authors, contributor.CreditName.Value)
+			}
+		}
+		writeBibTeXField(b, "author", strings.Join(authors, " and "))
+	}
+	switch entryType {
+	case "article":
+		writeBibTeXField(b, "journal", container)
+	case "incollection", "inproceedings":
+		writeBibTeXField(b, "booktitle", container)
+	}
+	if date != nil && date.Year != nil {
+		writeBibTeXField(b, "year", date.Year.Value)
+	}
+	// DOIs are read verbatim by BibTeX styles, so only braces are removed
+	if doi, ok := selfDOI(ids); ok {
+		if doi = strings.NewReplacer("{", "", "}", "").Replace(doi); doi != "" {
+			fmt.Fprintf(b, "  doi = {%s},\n", doi)
+		}
+	}
+
+	b.WriteString("}\n\n")
+}
+
+// bibTeXEscaper escapes the characters LaTeX treats specially. Braces are
+// dropped, since an unbalanced one would break the entry.
+var bibTeXEscaper = strings.NewReplacer(
+	`\`, `\textbackslash{}`, "{", "", "}", "",
+	"&", `\&`, "%", `\%`, "$", `\$`, "#", `\#`, "_", `\_`,
+)
+
+func writeBibTeXField(b *strings.Builder, name, value string) {
+	value = strings.Join(strings.Fields(value), " ")
+	if value == "" {
+		return
+	}
+	fmt.Fprintf(b, "  %s = {%s},\n", name, bibTeXEscaper.Replace(value))
+}
+
+// cslTypes maps ORCID work types to Citation Style Language item types.
+// Types without a CSL counterpart are exported as "document".
+var cslTypes = map[WorkType]string{
+	WorkTypeBook:                 "book",
+	WorkTypeBookChapter:          "chapter",
+	WorkTypeBookReview:           "review-book",
+	WorkTypeCartographicMaterial: "map",
+	WorkTypeConferenceAbstract:   "paper-conference",
+	WorkTypeConferencePaper:      "paper-conference",
+	WorkTypeConferencePoster:     "poster",
+	WorkTypeDataSet:              "dataset",
+	WorkTypeDictionaryEntry:      "entry-dictionary",
+	WorkTypeDissertationThesis:   "thesis",
+	WorkTypeEditedBook:           "book",
+	WorkTypeEncyclopediaEntry:    "entry-encyclopedia",
+	WorkTypeImage:                "graphic",
+	WorkTypeJournalArticle:       "article-journal",
+	WorkTypeLectureSpeech:        "speech",
+	WorkTypeMagazineArticle:      "article-magazine",
+	WorkTypeManual:               "book",
+	WorkTypeMovingImage:          "motion_picture",
+	WorkTypeMusicalComposition:   "musical_score",
+	WorkTypeNewsletterArticle:    "article",
+	WorkTypeNewspaperArticle:     "article-newspaper",
+	WorkTypeOnlineResource:       "webpage",
+	WorkTypePatent:               "patent",
+	WorkTypePreprint:             "article",
+	WorkTypePublicSpeech:         "speech",
+	WorkTypeReport:               "report",
+	WorkTypeReview:               "review",
+	WorkTypeSoftware:             "software",
+	WorkTypeSound:                "song",
+	WorkTypeStandardsAndPolicy:   "standard",
+	WorkTypeTechnicalStandard:    "standard",
+	WorkTypeTranslation:          "book",
+	WorkTypeWebsite:              "webpage",
+	WorkTypeWorkingPaper:         "report",
+}
+
+// ToCSL returns the work as a Citation Style Language JSON item, ready to be
+// encoded for citeproc processors. Contributors become authors, split into
+// family and given names when the credit name has the form "Family, Given".
+func (w *Work) ToCSL() (map[string]interface{}, error) {
+	if w == nil {
+		return nil, fmt.Errorf("work is nil")
+	}
+
+	item := cslItem(w.PutCode, w.Type, w.Title, w.JournalTitle.Value, w.PublicationDate, w.ExternalIDs)
+	if w.Contributors != nil {
+		var authors []map[string]interface{}
+		for _, contributor := range w.Contributors.Contributor {
+			if contributor == nil || contributor.CreditName == nil {
+				continue
+			}
+			if author := cslName(contributor.CreditName.Value); author != nil {
+				authors = append(authors, author)
+			}
+		}
+		if len(authors) > 0 {
+			item["author"] = authors
+		}
+	}
+	return item, nil
+}
+
+// ToCSLList returns one CSL JSON item per work group, built from the group's
+// preferred summary. Summaries carry no contributors, so the items have no
+// authors; use Work.ToCSL on full works when they are needed.
+func (w *Works) ToCSLList() ([]map[string]interface{}, error) {
+	if w == nil {
+		return nil, fmt.Errorf("works is nil")
+	}
+
+	items := []map[string]interface{}{}
+	for _, summary := range w.PreferredSummaries() {
+		items = append(items, cslItem(summary.PutCode, summary.Type, summary.Title,
+			summary.JournalTitle.Value, summary.PublicationDate, summary.ExternalIDs))
+	}
+	return items, nil
+}
+
+func cslItem(putCode int64, workType WorkType, title *Title, container string, date *PublicationDate, ids *ExternalIDs) map[string]interface{} {
+	cslType, ok := cslTypes[workType]
+	if !ok {
+		cslType = "document"
+	}
+	item := map[string]interface{}{
+		"id":   strconv.FormatInt(putCode, 10),
+		"type": cslType,
+	}
+
+	if title != nil && title.Title != nil {
+		setCSLString(item, "title", title.Title.Value)
+	}
+	setCSLString(item, "container-title", container)
+	if doi, ok := selfDOI(ids); ok {
+		setCSLString(item, "DOI", doi)
+	}
+	if parts := cslDateParts(date); parts != nil {
+		item["issued"] = map[string]interface{}{"date-parts": [][]int{parts}}
+	}
+	return item
+}
+
+func setCSLString(item map[string]interface{}, key, value string) {
+	value = strings.Join(strings.Fields(value), " ")
+	if value != "" {
+		item[key] = value
+	}
+}
+
+// cslDateParts returns the year, month and day of date, in order, stopping
+// at the first part that is missing or not a number.
+func cslDateParts(date *PublicationDate) []int {
+	if date == nil {
+		return nil
+	}
+	var values []string
+	if date.Year != nil {
+		values = append(values, date.Year.Value)
+		if date.Month != nil {
+			values = append(values, date.Month.Value)
+			if date.Day != nil {
+				values = append(values, date.Day.Value)
+			}
+		}
+	}
+
+	var parts []int
+	for _, value := range values {
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			break
+		}
+		parts = append(parts, n)
+	}
+	return parts
+}
+
+// cslName returns a CSL name for a credit name, split into family and given
+// names when written "Family, Given" and kept whole otherwise.
+func cslName(creditName string) map[string]interface{} {
+	creditName = strings.Join(strings.Fields(creditName), " ")
+	if creditName == "" {
+		return nil
+	}
+	family, given, ok := strings.Cut(creditName, ",")
+	family, given = strings.TrimSpace(family), strings.TrimSpace(given)
+	if !ok || family == "" || given == "" {
+		return map[string]interface{}{"literal": creditName}
+	}
+	return map[string]interface{}{"family": family, "given": given}
+}
+
+type schemaOrgPerson struct {
+	Context     string                  `json:"@context"`
+	Type        string                  `json:"@type"`
+	ID          string                  `json:"@id,omitempty"`
+	Name        string                  `json:"name,omitempty"`
+	Affiliation []schemaOrgOrganization `json:"affiliation,omitempty"`
+	SameAs      []string                `json:"sameAs,omitempty"`
+}
+
+type schemaOrgOrganization struct {
+	Type string `json:"@type"`
+	Name string `json:"name"`
+}
+
+// ToSchemaOrgJSONLD returns the record as a schema.org Person in JSON-LD, for
+// embedding in profile pages. The @id is the ORCID URI, affiliation lists
+// the organizations of current employments (those without an end date), and
+// sameAs holds the URLs of the person's external identifiers, such as a
+// Scopus author page.
+func (r *Record) ToSchemaOrgJSONLD() ([]byte, error) {
+	if r == nil {
+		return nil, fmt.Errorf("record is nil")
+	}
+
+	person := schemaOrgPerson{
+		Context: "https://schema.org",
+		Type:    "Person",
+		Name:    r.FullName(),
+	}
+	if r.OrcidIdentifier != nil {
+		person.ID = r.OrcidIdentifier.URI
+		if person.ID == "" {
+			person.ID = CanonicalURL(string(r.OrcidIdentifier.Path), false)
+		}
+	}
+
+	if r.ActivitiesSummary != nil {
+		seen := make(map[string]bool)
+		for _, employment := range r.ActivitiesSummary.Employments.Summaries() {
+			if employment.EndDate != nil || employment.Organization == nil {
+				continue
+			}
+			name := strings.TrimSpace(employment.Organization.Name)
+			if name == "" || seen[name] {
+				continue
+			}
+			seen[name] = true
+			person.Affiliation = append(person.Affiliation, schemaOrgOrganization{Type: "Organization", Name: name})
+		}
+	}
+
+	if r.Person != nil && r.Person.ExternalIdentifiers != nil {
+		for _, id := range r.Person.ExternalIdentifiers.ExternalIdentifier {
+			if id == nil || id.ExternalIdentifierURL == nil {
+				continue
+			}
+			if u := strings.TrimSpace(id.ExternalIdentifierURL.Value); u != "" && !slices.Contains(person.SameAs, u) {
+				person.SameAs = append(person.SameAs, u)
+			}
+		}
+	}
+
+	return json.Marshal(person)
+}
+
+// WriteSearchCSV writes expanded search results as CSV with a header row and
+// the columns orcid-id, given-names, family-names, credit-name, email and
+// institution-name. Only the first email is written, as expanded search does
+// not say which is primary; institutions are joined with "; ".
+func WriteSearchCSV(w io.Writer, results []*ExpandedSearchRecord) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"orcid-id", "given-names", "family-names", "credit-name", "email", "institution-name"})
+	for _, result := range results {
+		if result == nil {
+			continue
+		}
+		var email string
+		if len(result.Email) > 0 {
+			email = result.Email[0]
+		}
+		cw.Write([]string{result.OrcidID, result.GivenNames, result.FamilyNames, result.CreditName,
+			email, strings.Join(result.InstitutionName, "; ")})
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteSearchRecordsCSV writes the results of a regular search as CSV with a
+// header row and the columns orcid-id and uri. Regular search returns only
+// identifiers; use ExpandedSearch and WriteSearchCSV to include names,
+// emails and institutions.
+func WriteSearchRecordsCSV(w io.Writer, results []*SearchRecord) error {
+	cw := csv.NewWriter(w)
+	cw.Write([]string{"orcid-id", "uri"})
+	for _, result := range results {
+		if result == nil || result.OrcidIdentifier == nil {
+			continue
+		}
+		cw.Write([]string{string(result.OrcidIdentifier.Path), result.OrcidIdentifier.URI})
+	}
+	cw.Flush()
+	return cw.Error()
+}
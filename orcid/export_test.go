@@ -0,0 +1,323 @@
+package orcid
+
+import (
+	"encoding/json"
+	"strings"
+	"testing"
+)
+
+func TestWorksToRIS(t *testing.T) {
+	var works Works
+	err := json.Unmarshal([]byte(`{
+		"group": [{
+			"work-summary": [{
+				"put-code": 12345,
+				"display-index": "1",
+				"title": {"title": {"value": "Test Publication"}},
+				"journal-title": {"value": "Journal of Tests"},
+				"type": "journal-article",
+				"publication-date": {"year": {"value": "2021"}},
+				"external-ids": {"external-id": [
+					{"external-id-type": "doi", "external-id-value": "https://doi.org/10.1000/TEST", "external-id-relationship": "self"}
+				]}
+			}, {
+				"put-code": 12346,
+				"display-index": "0",
+				"title": {"title": {"value": "Test Publication (other source)"}},
+				"type": "journal-article"
+			}]
+		}]
+	}`), &works)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	ris, err := WorksToRIS(&works)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "TY  - JOUR\n" +
+		"TI  - Test Publication\n" +
+		"JO  - Journal of Tests\n" +
+		"PY  - 2021\n" +
+		"DO  - 10.1000/test\n" +
+		"ER  - \n\n"
+	if ris != expected {
+		t.Errorf("Expected RIS:\n%s\nGot:\n%s", expected, ris)
+	}
+}
+
+func TestWorkToRISAuthors(t *testing.T) {
+	work := &Work{
+		Type:  "book",
+		Title: &Title{Title: &TitleValue{Value: "A Book"}},
+		Contributors: &Contributors{Contributor: []*Contributor{
+			{CreditName: &CreditName{Value: "Doe, Jane"}},
+			{CreditName: &CreditName{Value: "Roe, Richard"}},
+		}},
+	}
+
+	ris, err := WorkToRIS(work)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.HasPrefix(ris, "TY  - BOOK\n") {
+		t.Errorf("Expected BOOK type, got:\n%s", ris)
+	}
+	if !strings.Contains(ris, "AU  - Doe, Jane\nAU  - Roe, Richard\n") {
+		t.Errorf("Expected two AU lines, got:\n%s", ris)
+	}
+	if strings.Contains(ris, "JO  -") || strings.Contains(ris, "DO  -") {
+		t.Errorf("Expected missing fields to be omitted, got:\n%s", ris)
+	}
+}
+
+func TestWorkToCSL(t *testing.T) {
+	work := &Work{
+		PutCode:      12345,
+		Type:         WorkTypeJournalArticle,
+		Title:        &Title{Title: &TitleValue{Value: "Test Publication"}},
+		JournalTitle: JournalTitle{Value: "Journal of Tests"},
+		PublicationDate: &PublicationDate{
+			Year:  &Year{Value: "2021"},
+			Month: &Month{Value: "03"},
+		},
+		ExternalIDs: &ExternalIDs{ExternalID: []*ExternalID{
+			{ExternalIDType: "doi", ExternalIDValue: "https://doi.org/10.1000/TEST"},
+		}},
+		Contributors: &Contributors{Contributor: []*Contributor{
+			{CreditName: &CreditName{Value: "Doe, Jane"}},
+			{CreditName: &CreditName{Value: "Research Consortium"}},
+		}},
+	}
+
+	item, err := work.ToCSL()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	data, err := json.Marshal(item)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `{"DOI":"10.1000/test",` +
+		`"author":[{"family":"Doe","given":"Jane"},{"literal":"Research Consortium"}],` +
+		`"container-title":"Journal of Tests","id":"12345",` +
+		`"issued":{"date-parts":[[2021,3]]},` +
+		`"title":"Test Publication","type":"article-journal"}`
+	if string(data) != expected {
+		t.Errorf("Expected CSL:\n%s\nGot:\n%s", expected, data)
+	}
+
+	if _, err := (*Work)(nil).ToCSL(); err == nil {
+		t.Error("Expected an error for a nil work")
+	}
+}
+
+func TestWorksToCSLList(t *testing.T) {
+	works := &Works{WorkGroup: []*WorkGroup{
+		{WorkSummary: []*WorkSummary{
+			{PutCode: 1, Type: WorkTypeBook, Title: &Title{Title: &TitleValue{Value: "Other copy"}}},
+			{PutCode: 2, Type: WorkTypeBook, Title: &Title{Title: &TitleValue{Value: "Preferred copy"}}, DisplayIndex: "1"},
+		}},
+		{WorkSummary: []*WorkSummary{
+			{PutCode: 3, Type: WorkTypeArtisticPerformance},
+		}},
+	}}
+
+	items, err := works.ToCSLList()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(items) != 2 {
+		t.Fatalf("Expected 2 items, got %d", len(items))
+	}
+	if items[0]["id"] != "2" || items[0]["title"] != "Preferred copy" || items[0]["type"] != "book" {
+		t.Errorf("Expected the preferred book summary, got %v", items[0])
+	}
+	if items[1]["type"] != "document" {
+		t.Errorf("Expected unmapped type to become document, got %v", items[1]["type"])
+	}
+	if _, ok := items[1]["issued"]; ok {
+		t.Errorf("Expected no issued date, got %v", items[1]["issued"])
+	}
+}
+
+func TestRecordToSchemaOrgJSONLD(t *testing.T) {
+	var record Record
+	err := json.Unmarshal([]byte(`{
+		"orcid-identifier": {"uri": "https://orcid.org/0000-0002-1825-0097", "path": "0000-0002-1825-0097"},
+		"person": {
+			"name": {"given-names": {"value": "Josiah"}, "family-name": {"value": "Carberry"}},
+			"external-identifiers": {"external-identifier": [{
+				"external-id-type": "Scopus Author ID",
+				"external-id-value": "7007156898",
+				"external-id-url": {"value": "http://www.scopus.com/inward/authorDetails.url?authorID=7007156898"}
+			}]}
+		},
+		"activities-summary": {
+			"employments": {"affiliation-group": [
+				{"summaries": [{"employment-summary": {"organization": {"name": "Brown University"}}}]},
+				{"summaries": [{"employment-summary": {
+					"organization": {"name": "Wesleyan University"},
+					"end-date": {"year": {"value": "1990"}}
+				}}]}
+			]}
+		}
+	}`), &record)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	data, err := record.ToSchemaOrgJSONLD()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := `{"@context":"https://schema.org","@type":"Person",` +
+		`"@id":"https://orcid.org/0000-0002-1825-0097","name":"Josiah Carberry",` +
+		`"affiliation":[{"@type":"Organization","name":"Brown University"}],` +
+		`"sameAs":["http://www.scopus.com/inward/authorDetails.url?authorID=7007156898"]}`
+	if string(data) != expected {
+		t.Errorf("Expected JSON-LD:\n%s\nGot:\n%s", expected, data)
+	}
+
+	if _, err := (*Record)(nil).ToSchemaOrgJSONLD(); err == nil {
+		t.Error("Expected an error for a nil record")
+	}
+}
+
+func TestWriteSearchCSV(t *testing.T) {
+	results := []*ExpandedSearchRecord{
+		{
+			OrcidID:         "0000-0002-1825-0097",
+			GivenNames:      "Josiah",
+			FamilyNames:     "Carberry",
+			Email:           []string{"j.carberry@example.edu", "other@example.com"},
+			InstitutionName: []string{"Brown University", "Wesleyan University"},
+		},
+		nil,
+		{OrcidID: "0000-0002-1694-233X", CreditName: "Smith, \"Jo\""},
+	}
+
+	var b strings.Builder
+	if err := WriteSearchCSV(&b, results); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "orcid-id,given-names,family-names,credit-name,email,institution-name\n" +
+		"0000-0002-1825-0097,Josiah,Carberry,,j.carberry@example.edu,Brown University; Wesleyan University\n" +
+		"0000-0002-1694-233X,,,\"Smith, \"\"Jo\"\"\",,\n"
+	if b.String() != expected {
+		t.Errorf("Expected CSV:\n%s\nGot:\n%s", expected, b.String())
+	}
+}
+
+func TestWriteSearchRecordsCSV(t *testing.T) {
+	results := []*SearchRecord{
+		{OrcidIdentifier: &OrcidIdentifier{Path: "0000-0002-1825-0097", URI: "https://orcid.org/0000-0002-1825-0097"}},
+		{},
+	}
+
+	var b strings.Builder
+	if err := WriteSearchRecordsCSV(&b, results); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := "orcid-id,uri\n0000-0002-1825-0097,https://orcid.org/0000-0002-1825-0097\n"
+	if b.String() != expected {
+		t.Errorf("Expected CSV:\n%s\nGot:\n%s", expected, b.String())
+	}
+}
+
+func TestWorkToBibTeX(t *testing.T) {
+	work := &Work{
+		PutCode:         12345,
+		Type:            WorkTypeJournalArticle,
+		Title:           &Title{Title: &TitleValue{Value: "Costs & Benefits of 100% {Coverage}"}},
+		JournalTitle:    JournalTitle{Value: "Journal of Tests"},
+		PublicationDate: &PublicationDate{Year: &Year{Value: "2021"}},
+		ExternalIDs: &ExternalIDs{ExternalID: []*ExternalID{
+			{ExternalIDType: "doi", ExternalIDValue: "10.1000/test_1"},
+		}},
+		Contributors: &Contributors{Contributor: []*Contributor{
+			{CreditName: &CreditName{Value: "Doe, Jane"}},
+			{CreditName: &CreditName{Value: "Roe, Richard"}},
+		}},
+	}
+
+	bib, err := WorkToBibTeX(work)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "@article{orcid12345,\n" +
+		"  title = {Costs \\& Benefits of 100\\% Coverage},\n" +
+		"  author = {Doe, Jane and Roe, Richard},\n" +
+		"  journal = {Journal of Tests},\n" +
+		"  year = {2021},\n" +
+		"  doi = {10.1000/test_1},\n" +
+		"}\n\n"
+	if bib != expected {
+		t.Errorf("Expected BibTeX:\n%s\nGot:\n%s", expected, bib)
+	}
+
+	work.Citation = &Citation{CitationType: "bibtex", CitationValue: "@book{stored, title={Stored}}"}
+	bib, err = WorkToBibTeX(work)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if bib != "@book{stored, title={Stored}}\n\n" {
+		t.Errorf("Expected the stored BibTeX citation, got:\n%s", bib)
+	}
+}
+
+func TestWorkToBibTeXChapterDOI(t *testing.T) {
+	work := &Work{
+		PutCode:      12345,
+		Type:         WorkTypeBookChapter,
+		Title:        &Title{Title: &TitleValue{Value: "A Chapter"}},
+		JournalTitle: JournalTitle{Value: "A Book"},
+		ExternalIDs: &ExternalIDs{ExternalID: []*ExternalID{
+			{ExternalIDType: "doi", ExternalIDValue: "10.1000/book", ExternalIDRelationship: "part-of"},
+			{ExternalIDType: "doi", ExternalIDValue: "https://doi.org/10.1000/CHAPTER", ExternalIDRelationship: "self"},
+		}},
+	}
+
+	bib, err := WorkToBibTeX(work)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "@incollection{orcid12345,\n" +
+		"  title = {A Chapter},\n" +
+		"  booktitle = {A Book},\n" +
+		"  doi = {10.1000/chapter},\n" +
+		"}\n\n"
+	if bib != expected {
+		t.Errorf("Expected BibTeX:\n%s\nGot:\n%s", expected, bib)
+	}
+}
+
+func TestWorksToBibTeX(t *testing.T) {
+	works := &Works{WorkGroup: []*WorkGroup{
+		{WorkSummary: []*WorkSummary{
+			{PutCode: 1, Type: WorkTypeConferencePaper, Title: &Title{Title: &TitleValue{Value: "A Paper"}},
+				JournalTitle: JournalTitle{Value: "Proceedings of Tests"}, DisplayIndex: "1"},
+			{PutCode: 3, Type: WorkTypeConferencePaper, Title: &Title{Title: &TitleValue{Value: "A Paper (other source)"}},
+				DisplayIndex: "0"},
+		}},
+		{WorkSummary: []*WorkSummary{
+			{PutCode: 2, Type: WorkTypeSoftware, Title: &Title{Title: &TitleValue{Value: "A Tool"}}},
+		}},
+	}}
+
+	bib, err := WorksToBibTeX(works)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	expected := "@inproceedings{orcid1,\n  title = {A Paper},\n  booktitle = {Proceedings of Tests},\n}\n\n" +
+		"@misc{orcid2,\n  title = {A Tool},\n}\n\n"
+	if bib != expected {
+		t.Errorf("Expected BibTeX:\n%s\nGot:\n%s", expected, bib)
+	}
+}
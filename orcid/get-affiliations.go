@@ -0,0 +1,141 @@
+package orcid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"sync"
+)
+
+// DefaultItemConcurrency is the number of single-item requests made at once
+// when expanding a list of put-codes that has no bulk endpoint.
+const DefaultItemConcurrency = 4
+
+// GetEducation fetches a single education by put-code.
+func (c *Client) GetEducation(ctx context.Context, orcidID string, putCode string) (*EducationSummary, error) {
+	return getItem[EducationSummary](ctx, c, fmt.Sprintf("%s/%s/education/%s", c.apiURL, orcidID, putCode))
+}
+
+// GetEmployment fetches a single employment by put-code.
+func (c *Client) GetEmployment(ctx context.Context, orcidID string, putCode string) (*EmploymentSummary, error) {
+	return getItem[EmploymentSummary](ctx, c, fmt.Sprintf("%s/%s/employment/%s", c.apiURL, orcidID, putCode))
+}
+
+// GetDistinction fetches a single distinction by put-code.
+func (c *Client) GetDistinction(ctx context.Context, orcidID string, putCode string) (*DistinctionSummary, error) {
+	return getItem[DistinctionSummary](ctx, c, fmt.Sprintf("%s/%s/distinction/%s", c.apiURL, orcidID, putCode))
+}
+
+// GetInvitedPosition fetches a single invited position by put-code.
+func (c *Client) GetInvitedPosition(ctx context.Context, orcidID string, putCode string) (*InvitedPositionSummary, error) {
+	return getItem[InvitedPositionSummary](ctx, c, fmt.Sprintf("%s/%s/invited-position/%s", c.apiURL, orcidID, putCode))
+}
+
+// GetMembership fetches a single membership by put-code.
+func (c *Client) GetMembership(ctx context.Context, orcidID string, putCode string) (*MembershipSummary, error) {
+	return getItem[MembershipSummary](ctx, c, fmt.Sprintf("%s/%s/membership/%s", c.apiURL, orcidID, putCode))
+}
+
+// GetQualification fetches a single qualification by put-code.
+func (c *Client) GetQualification(ctx context.Context, orcidID string, putCode string) (*QualificationSummary, error) {
+	return getItem[QualificationSummary](ctx, c, fmt.Sprintf("%s/%s/qualification/%s", c.apiURL, orcidID, putCode))
+}
+
+// GetService fetches a single service by put-code.
+func (c *Client) GetService(ctx context.Context, orcidID string, putCode string) (*ServiceSummary, error) {
+	return getItem[ServiceSummary](ctx, c, fmt.Sprintf("%s/%s/service/%s", c.apiURL, orcidID, putCode))
+}
+
+// GetFunding fetches a single funding item by put-code.
+func (c *Client) GetFunding(ctx context.Context, orcidID string, putCode string) (*FundingSummary, error) {
+	return getItem[FundingSummary](ctx, c, fmt.Sprintf("%s/%s/funding/%s", c.apiURL, orcidID, putCode))
+}
+
+// GetPeerReview fetches a single peer review by put-code.
+func (c *Client) GetPeerReview(ctx context.Context, orcidID string, putCode string) (*PeerReviewSummary, error) {
+	return getItem[PeerReviewSummary](ctx, c, fmt.Sprintf("%s/%s/peer-review/%s", c.apiURL, orcidID, putCode))
+}
+
+// getItem fetches url and decodes the response into a new T.
+func getItem[T any](ctx context.Context, c *Client, url string) (*T, error) {
+	resp, err := c.doRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var item T
+	if err := c.decodeResponse(resp.Body, &item); err != nil {
+		return nil, err
+	}
+
+	return &item, nil
+}
+
+// GetEducationsByPutCodes fetches the full education for each put-code.
+// ORCID has no bulk endpoint for affiliations, so the items are requested
+// individually, DefaultItemConcurrency at a time. The result follows the
+// order of putCodes but omits the items that could not be fetched, so its
+// indices do not match putCodes when any fail; the failures are reported in
+// the error.
+func (c *Client) GetEducationsByPutCodes(ctx context.Context, orcidID string, putCodes []int64) ([]*EducationSummary, error) {
+	return fetchEach(ctx, putCodes, func(ctx context.Context, putCode int64) (*EducationSummary, error) {
+		return c.GetEducation(ctx, orcidID, strconv.FormatInt(putCode, 10))
+	})
+}
+
+// GetEmploymentsByPutCodes fetches the full employment for each put-code in
+// the same way as GetEducationsByPutCodes.
+func (c *Client) GetEmploymentsByPutCodes(ctx context.Context, orcidID string, putCodes []int64) ([]*EmploymentSummary, error) {
+	return fetchEach(ctx, putCodes, func(ctx context.Context, putCode int64) (*EmploymentSummary, error) {
+		return c.GetEmployment(ctx, orcidID, strconv.FormatInt(putCode, 10))
+	})
+}
+
+// fetchEach calls fetch for every put-code using a bounded pool of workers
+// and returns the successful results in put-code order.
+func fetchEach[T any](ctx context.Context, putCodes []int64, fetch func(context.Context, int64) (*T, error)) ([]*T, error) {
+	items := make([]*T, len(putCodes))
+	errs := make([]error, len(putCodes))
+
+	sem := make(chan struct{}, DefaultItemConcurrency)
+	var wg sync.WaitGroup
+	for i, putCode := range putCodes {
+		wg.Add(1)
+		go func(i int, putCode int64) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+
+			item, err := fetch(ctx, putCode)
+			if err != nil {
+				errs[i] = fmt.Errorf("put-code %d: %w", putCode, err)
+				return
+			}
+			items[i] = item
+		}(i, putCode)
+	}
+	wg.Wait()
+
+	// Once ctx is done every outstanding item fails the same way, so report
+	// that once instead of per put-code
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	result := make([]*T, 0, len(items))
+	for _, item := range items {
+		if item != nil {
+			result = append(result, item)
+		}
+	}
+	return result, errors.Join(errs...)
+}
@@ -0,0 +1,93 @@
+package orcid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestGetEmploymentsByPutCodes(t *testing.T) {
+	var mu sync.Mutex
+	var paths []string
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		paths = append(paths, r.URL.Path)
+		mu.Unlock()
+
+		putCode := strings.TrimPrefix(r.URL.Path, "/v3.0/0000-0002-1825-0097/employment/")
+		if putCode == "404" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"put-code": %s, "role-title": "Role %s", "organization": {"name": "Org"}}`, putCode, putCode)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(100),
+	)
+	ctx := context.Background()
+
+	employments, err := client.GetEmploymentsByPutCodes(ctx, "0000-0002-1825-0097", []int64{11, 22, 33})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(paths) != 3 {
+		t.Errorf("Expected 3 per-item requests, got %d", len(paths))
+	}
+	if len(employments) != 3 {
+		t.Fatalf("Expected 3 employments, got %d", len(employments))
+	}
+	for i, putCode := range []int64{11, 22, 33} {
+		if employments[i].PutCode != putCode {
+			t.Errorf("Expected put-code %d at index %d, got %d", putCode, i, employments[i].PutCode)
+		}
+		if employments[i].RoleTitle != fmt.Sprintf("Role %d", putCode) {
+			t.Errorf("Expected role title for put-code %d, got %s", putCode, employments[i].RoleTitle)
+		}
+	}
+
+	employments, err = client.GetEmploymentsByPutCodes(ctx, "0000-0002-1825-0097", []int64{11, 404})
+	if err == nil || !strings.Contains(err.Error(), "put-code 404") {
+		t.Errorf("Expected error for put-code 404, got: %v", err)
+	}
+	if len(employments) != 1 {
+		t.Errorf("Expected 1 partial result, got %d", len(employments))
+	}
+}
+
+func TestGetEducationsByPutCodes(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if !strings.HasPrefix(r.URL.Path, "/v3.0/0000-0002-1825-0097/education/") {
+			t.Errorf("Unexpected path %s", r.URL.Path)
+		}
+		putCode := strings.TrimPrefix(r.URL.Path, "/v3.0/0000-0002-1825-0097/education/")
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"put-code": %s}`, putCode)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(100),
+	)
+
+	educations, err := client.GetEducationsByPutCodes(context.Background(), "0000-0002-1825-0097", []int64{5, 6})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(educations) != 2 || educations[0].PutCode != 5 || educations[1].PutCode != 6 {
+		t.Errorf("Expected educations with put-codes 5 and 6, got %+v", educations)
+	}
+}
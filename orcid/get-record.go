@@ -2,10 +2,14 @@ package orcid
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"net/http"
+	"strconv"
 	"strings"
+	"sync"
+	"time"
 )
 
 func (c *Client) GetRecord(ctx context.Context, orcidID string) (*Record, error) {
@@ -17,17 +21,167 @@ func (c *Client) GetRecord(ctx context.Context, orcidID string) (*Record, error)
 	}
 	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
+	var record Record
+	if err := c.decodeResponse(resp.Body, &record); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// GetRecordSummary fetches the record summary: the name, external
+// identifiers and activity counts, without the activities themselves. It is
+// much cheaper than GetRecord when only totals are needed.
+func (c *Client) GetRecordSummary(ctx context.Context, orcidID string) (*RecordSummary, error) {
+	url := fmt.Sprintf("%s/%s/record-summary", c.apiURL, orcidID)
+
+	resp, err := c.doRequest(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
-	var record Record
-	if err := c.unmarshalResponse(data, &record); err != nil {
+	var summary RecordSummary
+	if err := c.decodeResponse(resp.Body, &summary); err != nil {
 		return nil, err
 	}
 
-	return &record, nil
+	return &summary, nil
+}
+
+// GetRecordResolved fetches a record, following redirects to a different
+// ORCID iD such as those for deprecated records that were merged into
+// another. It returns the record together with the iD that was actually
+// served, which differs from orcidID when a redirect was followed.
+func (c *Client) GetRecordResolved(ctx context.Context, orcidID string) (*Record, string, error) {
+	resolvedID := orcidID
+	for hops := 0; ; hops++ {
+		record, err := c.GetRecord(ctx, resolvedID)
+
+		var deprecated *ErrDeprecatedRecord
+		if errors.As(err, &deprecated) {
+			if hops >= 5 {
+				return nil, resolvedID, err
+			}
+			resolvedID = deprecated.PrimaryID
+			continue
+		}
+		if err != nil {
+			return nil, resolvedID, err
+		}
+
+		if record.OrcidIdentifier != nil && ValidateOrcidID(string(record.OrcidIdentifier.Path)) == nil {
+			resolvedID = FormatOrcidID(string(record.OrcidIdentifier.Path))
+		}
+		return record, resolvedID, nil
+	}
+}
+
+// GetRecordIfModified fetches a record only if it changed after since. The
+// boolean reports whether a record was returned; when ORCID answers with
+// HTTP 304 the result is nil, false and a nil error.
+func (c *Client) GetRecordIfModified(ctx context.Context, orcidID string, since time.Time) (*Record, bool, error) {
+	url := fmt.Sprintf("%s/%s/record", c.apiURL, orcidID)
+	header := http.Header{}
+	header.Set("If-Modified-Since", since.UTC().Format(http.TimeFormat))
+
+	resp, err := c.doRequestHeader(ctx, http.MethodGet, url, nil, header)
+	if err != nil {
+		return nil, false, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode == http.StatusNotModified {
+		return nil, false, nil
+	}
+
+	var record Record
+	if err := c.decodeResponse(resp.Body, &record); err != nil {
+		return nil, false, err
+	}
+
+	return &record, true, nil
+}
+
+// GetRecords fetches the records for many ORCID iDs, running at most
+// concurrency requests at a time, or DefaultItemConcurrency if concurrency is
+// not positive. All requests share the client's rate limiter.
+//
+// A failure for one iD, such as ErrNotFound for a deactivated record, is
+// reported in the error map and does not affect the others. Errors that
+// would fail every request, ErrUnauthorized or the client being closed,
+// cancel the outstanding requests, as does ctx being done; iDs that were not
+// fetched then carry that error.
+func (c *Client) GetRecords(ctx context.Context, orcidIDs []string, concurrency int) (map[string]*Record, map[string]error) {
+	if concurrency <= 0 {
+		concurrency = DefaultItemConcurrency
+	}
+
+	fetchCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		records  = make(map[string]*Record, len(orcidIDs))
+		errs     = make(map[string]error)
+		started  = make(map[string]bool, len(orcidIDs))
+		abortErr error
+		sem      = make(chan struct{}, concurrency)
+	)
+	for _, orcidID := range orcidIDs {
+		if started[orcidID] {
+			continue
+		}
+		started[orcidID] = true
+
+		wg.Add(1)
+		go func(orcidID string) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-fetchCtx.Done():
+				return
+			}
+
+			record, err := c.GetRecord(fetchCtx, orcidID)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err == nil {
+				records[orcidID] = record
+				return
+			}
+			if abortErr != nil && fetchCtx.Err() != nil && ctx.Err() == nil {
+				// Cancelled because of an earlier failure; filled in below
+				return
+			}
+			errs[orcidID] = err
+			if abortErr == nil && (errors.Is(err, ErrUnauthorized) || errors.Is(err, errClientClosed)) {
+				abortErr = err
+				cancel()
+			}
+		}(orcidID)
+	}
+	wg.Wait()
+
+	if abortErr == nil {
+		abortErr = ctx.Err()
+	}
+	if abortErr != nil {
+		for orcidID := range started {
+			if _, ok := records[orcidID]; ok {
+				continue
+			}
+			if _, ok := errs[orcidID]; !ok {
+				errs[orcidID] = abortErr
+			}
+		}
+	}
+
+	return records, errs
 }
 
 func (c *Client) GetRecordRaw(ctx context.Context, orcidID string) ([]byte, error) {
@@ -51,21 +205,37 @@ func (c *Client) GetPerson(ctx context.Context, orcidID string) (*Person, error)
 	}
 	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
+	var person Person
+	if err := c.decodeResponse(resp.Body, &person); err != nil {
+		return nil, err
+	}
+
+	return &person, nil
+}
+
+// GetActivities fetches the summaries of every activity section, the
+// activities-summary part of the record, without the person section.
+func (c *Client) GetActivities(ctx context.Context, orcidID string) (*ActivitiesSummary, error) {
+	url := fmt.Sprintf("%s/%s/activities", c.apiURL, orcidID)
+
+	resp, err := c.doRequest(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
-	var person Person
-	if err := c.unmarshalResponse(data, &person); err != nil {
+	var activities ActivitiesSummary
+	if err := c.decodeResponse(resp.Body, &activities); err != nil {
 		return nil, err
 	}
 
-	return &person, nil
+	return &activities, nil
 }
 
-func (c *Client) GetWorks(ctx context.Context, orcidID string) (*Works, error) {
-	url := fmt.Sprintf("%s/%s/works", c.apiURL, orcidID)
+// GetBiography fetches only the biography, which is cheaper than GetPerson
+// when the rest of the person section is not needed.
+func (c *Client) GetBiography(ctx context.Context, orcidID string) (*Biography, error) {
+	url := fmt.Sprintf("%s/%s/biography", c.apiURL, orcidID)
 
 	resp, err := c.doRequest(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -73,21 +243,36 @@ func (c *Client) GetWorks(ctx context.Context, orcidID string) (*Works, error) {
 	}
 	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
+	var biography Biography
+	if err := c.decodeResponse(resp.Body, &biography); err != nil {
+		return nil, err
+	}
+
+	return &biography, nil
+}
+
+// GetKeywords fetches only the keywords section of the person.
+func (c *Client) GetKeywords(ctx context.Context, orcidID string) (*Keywords, error) {
+	url := fmt.Sprintf("%s/%s/keywords", c.apiURL, orcidID)
+
+	resp, err := c.doRequest(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
-	var works Works
-	if err := c.unmarshalResponse(data, &works); err != nil {
+	var keywords Keywords
+	if err := c.decodeResponse(resp.Body, &keywords); err != nil {
 		return nil, err
 	}
 
-	return &works, nil
+	return &keywords, nil
 }
 
-func (c *Client) GetWork(ctx context.Context, orcidID string, putCode string) (*Work, error) {
-	url := fmt.Sprintf("%s/%s/work/%s", c.apiURL, orcidID, putCode)
+// GetEmails fetches the email addresses visible to the token. Addresses with
+// limited visibility need a /read-limited token; see Emails.VisibleTo.
+func (c *Client) GetEmails(ctx context.Context, orcidID string) (*Emails, error) {
+	url := fmt.Sprintf("%s/%s/email", c.apiURL, orcidID)
 
 	resp, err := c.doRequest(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -95,21 +280,33 @@ func (c *Client) GetWork(ctx context.Context, orcidID string, putCode string) (*
 	}
 	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
+	var emails Emails
+	if err := c.decodeResponse(resp.Body, &emails); err != nil {
+		return nil, err
+	}
+
+	return &emails, nil
+}
+
+func (c *Client) GetWorks(ctx context.Context, orcidID string) (*Works, error) {
+	url := fmt.Sprintf("%s/%s/works", c.apiURL, orcidID)
+
+	resp, err := c.doRequest(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
-	var work Work
-	if err := c.unmarshalResponse(data, &work); err != nil {
+	var works Works
+	if err := c.decodeResponse(resp.Body, &works); err != nil {
 		return nil, err
 	}
 
-	return &work, nil
+	return &works, nil
 }
 
-func (c *Client) GetEducations(ctx context.Context, orcidID string) (*Educations, error) {
-	url := fmt.Sprintf("%s/%s/educations", c.apiURL, orcidID)
+func (c *Client) GetWork(ctx context.Context, orcidID string, putCode string) (*Work, error) {
+	url := fmt.Sprintf("%s/%s/work/%s", c.apiURL, orcidID, putCode)
 
 	resp, err := c.doRequest(ctx, http.MethodGet, url, nil)
 	if err != nil {
@@ -117,13 +314,25 @@ func (c *Client) GetEducations(ctx context.Context, orcidID string) (*Educations
 	}
 	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
+	var work Work
+	if err := c.decodeResponse(resp.Body, &work); err != nil {
+		return nil, err
+	}
+
+	return &work, nil
+}
+
+func (c *Client) GetEducations(ctx context.Context, orcidID string) (*Educations, error) {
+	url := fmt.Sprintf("%s/%s/educations", c.apiURL, orcidID)
+
+	resp, err := c.doRequest(ctx, http.MethodGet, url, nil)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var educations Educations
-	if err := c.unmarshalResponse(data, &educations); err != nil {
+	if err := c.decodeResponse(resp.Body, &educations); err != nil {
 		return nil, err
 	}
 
@@ -139,13 +348,8 @@ func (c *Client) GetEmployments(ctx context.Context, orcidID string) (*Employmen
 	}
 	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
 	var employments Employments
-	if err := c.unmarshalResponse(data, &employments); err != nil {
+	if err := c.decodeResponse(resp.Body, &employments); err != nil {
 		return nil, err
 	}
 
@@ -161,13 +365,8 @@ func (c *Client) GetFundings(ctx context.Context, orcidID string) (*Fundings, er
 	}
 	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
 	var fundings Fundings
-	if err := c.unmarshalResponse(data, &fundings); err != nil {
+	if err := c.decodeResponse(resp.Body, &fundings); err != nil {
 		return nil, err
 	}
 
@@ -183,13 +382,8 @@ func (c *Client) GetPeerReviews(ctx context.Context, orcidID string) (*PeerRevie
 	}
 	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
 	var peerReviews PeerReviews
-	if err := c.unmarshalResponse(data, &peerReviews); err != nil {
+	if err := c.decodeResponse(resp.Body, &peerReviews); err != nil {
 		return nil, err
 	}
 
@@ -205,13 +399,8 @@ func (c *Client) GetDistinctions(ctx context.Context, orcidID string) (*Distinct
 	}
 	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
 	var distinctions Distinctions
-	if err := c.unmarshalResponse(data, &distinctions); err != nil {
+	if err := c.decodeResponse(resp.Body, &distinctions); err != nil {
 		return nil, err
 	}
 
@@ -227,13 +416,8 @@ func (c *Client) GetInvitedPositions(ctx context.Context, orcidID string) (*Invi
 	}
 	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
 	var invitedPositions InvitedPositions
-	if err := c.unmarshalResponse(data, &invitedPositions); err != nil {
+	if err := c.decodeResponse(resp.Body, &invitedPositions); err != nil {
 		return nil, err
 	}
 
@@ -249,13 +433,8 @@ func (c *Client) GetMemberships(ctx context.Context, orcidID string) (*Membershi
 	}
 	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
 	var memberships Memberships
-	if err := c.unmarshalResponse(data, &memberships); err != nil {
+	if err := c.decodeResponse(resp.Body, &memberships); err != nil {
 		return nil, err
 	}
 
@@ -271,13 +450,8 @@ func (c *Client) GetQualifications(ctx context.Context, orcidID string) (*Qualif
 	}
 	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
 	var qualifications Qualifications
-	if err := c.unmarshalResponse(data, &qualifications); err != nil {
+	if err := c.decodeResponse(resp.Body, &qualifications); err != nil {
 		return nil, err
 	}
 
@@ -293,13 +467,8 @@ func (c *Client) GetServices(ctx context.Context, orcidID string) (*Services, er
 	}
 	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
 	var services Services
-	if err := c.unmarshalResponse(data, &services); err != nil {
+	if err := c.decodeResponse(resp.Body, &services); err != nil {
 		return nil, err
 	}
 
@@ -315,13 +484,8 @@ func (c *Client) GetResearchResources(ctx context.Context, orcidID string) (*Res
 	}
 	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
 	var researchResources ResearchResources
-	if err := c.unmarshalResponse(data, &researchResources); err != nil {
+	if err := c.decodeResponse(resp.Body, &researchResources); err != nil {
 		return nil, err
 	}
 
@@ -335,6 +499,8 @@ func (c *Client) GetResearchResources(ctx context.Context, orcidID string) (*Res
 // Examples:
 //   - "/0000-0003-1401-2056/qualifications" -> calls GetQualifications
 //   - "/0000-0003-1401-2056/works" -> calls GetWorks
+//   - "/0000-0003-1401-2056/works/1,2,3" -> calls GetWorksByPutCodes
+//   - "/0000-0003-1401-2056/education/12345" -> calls GetEducation
 //   - "/0000-0003-1401-2056/person" -> calls GetPerson
 //   - "/0000-0003-1401-2056" or "/0000-0003-1401-2056/record" -> calls GetRecord
 func (c *Client) GetByPath(ctx context.Context, path Path) (interface{}, error) {
@@ -363,85 +529,138 @@ func (c *Client) GetByPath(ctx context.Context, path Path) (interface{}, error)
 	resourceParts := strings.SplitN(resourceType, "/", 2)
 	baseResource := resourceParts[0]
 
-	switch baseResource {
-	case "person":
-		return c.GetPerson(ctx, orcidID)
-	case "works":
-		return c.GetWorks(ctx, orcidID)
-	case "work":
-		if len(resourceParts) == 2 {
-			return c.GetWork(ctx, orcidID, resourceParts[1])
+	// Single activities, e.g. "/0000-0003-1401-2056/education/12345". The
+	// section name followed by a put-code is accepted as well, except that
+	// "/works/" followed by a comma-separated list is ORCID's bulk endpoint.
+	if len(resourceParts) == 2 {
+		putCode := resourceParts[1]
+		if strings.Contains(putCode, ",") {
+			if baseResource != "works" {
+				return nil, fmt.Errorf("%s path takes a single put-code: %s", baseResource, path)
+			}
+			putCodes, err := parsePutCodeList(putCode)
+			if err != nil {
+				return nil, fmt.Errorf("invalid put-codes in path %s: %w", path, err)
+			}
+			return c.GetWorksByPutCodes(ctx, orcidID, putCodes)
 		}
-		return nil, fmt.Errorf("work path requires put-code: %s", path)
-	case "educations":
-		return c.GetEducations(ctx, orcidID)
-	case "employments":
-		return c.GetEmployments(ctx, orcidID)
-	case "fundings":
-		return c.GetFundings(ctx, orcidID)
-	case "peer-reviews":
-		return c.GetPeerReviews(ctx, orcidID)
-	case "distinctions":
-		return c.GetDistinctions(ctx, orcidID)
-	case "invited-positions":
-		return c.GetInvitedPositions(ctx, orcidID)
-	case "memberships":
-		return c.GetMemberships(ctx, orcidID)
-	case "qualifications":
-		return c.GetQualifications(ctx, orcidID)
-	case "services":
-		return c.GetServices(ctx, orcidID)
-	case "research-resources":
-		return c.GetResearchResources(ctx, orcidID)
-	case "activities":
-		// Activities summary is part of the record
-		record, err := c.GetRecord(ctx, orcidID)
-		if err != nil {
-			return nil, err
+		switch baseResource {
+		case "work", "works":
+			return c.GetWork(ctx, orcidID, putCode)
+		case "education", "educations":
+			return c.GetEducation(ctx, orcidID, putCode)
+		case "employment", "employments":
+			return c.GetEmployment(ctx, orcidID, putCode)
+		case "funding", "fundings":
+			return c.GetFunding(ctx, orcidID, putCode)
+		case "peer-review", "peer-reviews":
+			return c.GetPeerReview(ctx, orcidID, putCode)
+		case "distinction", "distinctions":
+			return c.GetDistinction(ctx, orcidID, putCode)
+		case "invited-position", "invited-positions":
+			return c.GetInvitedPosition(ctx, orcidID, putCode)
+		case "membership", "memberships":
+			return c.GetMembership(ctx, orcidID, putCode)
+		case "qualification", "qualifications":
+			return c.GetQualification(ctx, orcidID, putCode)
+		case "service", "services":
+			return c.GetService(ctx, orcidID, putCode)
 		}
-		return record.ActivitiesSummary, nil
-	case "biography", "other-names", "researcher-urls", "email", "address", "keywords", "external-identifiers":
-		// These are part of the person record
+	}
+
+	if get, ok := sectionGetters[baseResource]; ok {
+		// Any trailing put-code after a section without single items, such
+		// as "/email", resolves to the whole section
+		return get(c, ctx, orcidID)
+	}
+
+	switch baseResource {
+	case "work", "education", "employment", "funding", "peer-review", "distinction",
+		"invited-position", "membership", "qualification", "service":
+		return nil, fmt.Errorf("%s path requires put-code: %s", baseResource, path)
+	case "other-names", "researcher-urls", "address", "addresses", "external-identifiers":
+		// Sections without a dedicated method are read from the person.
+		// ORCID's own path is the singular "/address"; the plural matches
+		// the Person field it resolves to.
 		person, err := c.GetPerson(ctx, orcidID)
 		if err != nil {
 			return nil, err
 		}
 		switch baseResource {
-		case "biography":
-			return person.Biography, nil
 		case "other-names":
 			return person.OtherNames, nil
 		case "researcher-urls":
 			return person.ResearcherURLs, nil
-		case "email":
-			return person.Emails, nil
-		case "address":
+		case "address", "addresses":
 			return person.Addresses, nil
-		case "keywords":
-			return person.Keywords, nil
 		case "external-identifiers":
 			return person.ExternalIdentifiers, nil
 		}
 	default:
-		// Try to handle specific put-code paths for other resources
-		// e.g., "/0000-0003-1401-2056/keywords/1925453"
-		person, err := c.GetPerson(ctx, orcidID)
+		return nil, fmt.Errorf("unsupported resource type in path: %s", path)
+	}
+
+	return nil, fmt.Errorf("unsupported path: %s", path)
+}
+
+// parsePutCodeList parses put-codes separated by commas, as in the path of
+// the bulk works endpoint.
+func parsePutCodeList(list string) ([]int64, error) {
+	var putCodes []int64
+	for _, field := range strings.Split(list, ",") {
+		putCode, err := strconv.ParseInt(strings.TrimSpace(field), 10, 64)
 		if err != nil {
-			return nil, fmt.Errorf("unsupported resource type in path: %s", path)
+			return nil, err
 		}
+		putCodes = append(putCodes, putCode)
+	}
+	return putCodes, nil
+}
 
-		// Check if this is a specific item within person data
-		switch baseResource {
-		case "keywords":
-			return person.Keywords, nil
-		case "address":
-			return person.Addresses, nil
-		case "researcher-urls":
-			return person.ResearcherURLs, nil
-		default:
-			return nil, fmt.Errorf("unsupported resource type in path: %s", path)
-		}
+// sectionGetters maps each record section, and the path segments GetByPath
+// accepts for it, to the method that fetches the whole section. ORCID's own
+// paths use the singular "/email"; the plural is accepted as well.
+var sectionGetters = map[string]func(c *Client, ctx context.Context, orcidID string) (interface{}, error){
+	"record":             sectionGetter((*Client).GetRecord),
+	"person":             sectionGetter((*Client).GetPerson),
+	"activities":         sectionGetter((*Client).GetActivities),
+	"biography":          sectionGetter((*Client).GetBiography),
+	"keywords":           sectionGetter((*Client).GetKeywords),
+	"email":              sectionGetter((*Client).GetEmails),
+	"emails":             sectionGetter((*Client).GetEmails),
+	"works":              sectionGetter((*Client).GetWorks),
+	"educations":         sectionGetter((*Client).GetEducations),
+	"employments":        sectionGetter((*Client).GetEmployments),
+	"fundings":           sectionGetter((*Client).GetFundings),
+	"peer-reviews":       sectionGetter((*Client).GetPeerReviews),
+	"distinctions":       sectionGetter((*Client).GetDistinctions),
+	"invited-positions":  sectionGetter((*Client).GetInvitedPositions),
+	"memberships":        sectionGetter((*Client).GetMemberships),
+	"qualifications":     sectionGetter((*Client).GetQualifications),
+	"services":           sectionGetter((*Client).GetServices),
+	"research-resources": sectionGetter((*Client).GetResearchResources),
+}
+
+func sectionGetter[T any](get func(*Client, context.Context, string) (T, error)) func(*Client, context.Context, string) (interface{}, error) {
+	return func(c *Client, ctx context.Context, orcidID string) (interface{}, error) {
+		return get(c, ctx, orcidID)
 	}
+}
 
-	return nil, fmt.Errorf("unsupported path: %s", path)
+// FetchAll returns the complete contents of a single record section, such as
+// "works", "employments" or "peer-reviews", dispatching to the matching typed
+// method. The concrete type of the result is the one returned by that method,
+// except for "works": the full works are fetched through the bulk endpoint
+// with GetAllWorkDetails and returned as []*Work, possibly partial alongside
+// an error, as that method documents.
+func (c *Client) FetchAll(ctx context.Context, orcidID string, section string) (interface{}, error) {
+	section = strings.Trim(section, "/")
+	if section == "works" {
+		return c.GetAllWorkDetails(ctx, orcidID, 0)
+	}
+	get, ok := sectionGetters[section]
+	if !ok {
+		return nil, fmt.Errorf("unsupported section: %s", section)
+	}
+	return get(c, ctx, orcidID)
 }
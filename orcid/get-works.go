@@ -0,0 +1,168 @@
+package orcid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// MaxBulkPutCodes is the largest number of put-codes ORCID accepts in a single
+// bulk works request.
+const MaxBulkPutCodes = 100
+
+// getWorksBulk fetches up to MaxBulkPutCodes full works in one request. The
+// returned slice is aligned with the bulk response; items that ORCID could
+// not return are reported in itemErr, while err is set when the request as a
+// whole failed.
+func (c *Client) getWorksBulk(ctx context.Context, orcidID string, putCodes []int64) (works []*Work, itemErr error, err error) {
+	codes := make([]string, len(putCodes))
+	for i, putCode := range putCodes {
+		codes[i] = strconv.FormatInt(putCode, 10)
+	}
+	url := fmt.Sprintf("%s/%s/works/%s", c.apiURL, orcidID, strings.Join(codes, ","))
+
+	resp, err := c.doRequest(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer resp.Body.Close()
+
+	var bulk WorkBulk
+	if err := c.decodeResponse(resp.Body, &bulk); err != nil {
+		return nil, nil, err
+	}
+
+	var errs []error
+	for _, item := range bulk.Bulk {
+		switch {
+		case item.Work != nil:
+			works = append(works, item.Work)
+		case item.Error != nil:
+			errs = append(errs, bulkItemError(item.Error))
+		}
+	}
+
+	return works, errors.Join(errs...), nil
+}
+
+// GetWorksByPutCodes fetches the full works for the given put-codes through
+// the bulk works endpoint, MaxBulkPutCodes at a time. Works ORCID could not
+// return, such as deleted ones, are left out of the result and described in
+// the error; if a request fails outright, nothing is returned but that error.
+func (c *Client) GetWorksByPutCodes(ctx context.Context, orcidID string, putCodes []int64) ([]*Work, error) {
+	var works []*Work
+	var itemErrs []error
+	for start := 0; start < len(putCodes); start += MaxBulkPutCodes {
+		end := min(start+MaxBulkPutCodes, len(putCodes))
+
+		fetched, itemErr, err := c.getWorksBulk(ctx, orcidID, putCodes[start:end])
+		if err != nil {
+			return nil, err
+		}
+		works = append(works, fetched...)
+		if itemErr != nil {
+			itemErrs = append(itemErrs, itemErr)
+		}
+	}
+
+	return works, errors.Join(itemErrs...)
+}
+
+func bulkItemError(e *OrcidError) error {
+	return fmt.Errorf("bulk work error %d: %s", e.ErrorCode, e.DeveloperMessage)
+}
+
+// GetAllWorkDetails fetches the full Work for every work summary in a
+// profile. Put-codes are requested through the bulk endpoint in chunks of
+// MaxBulkPutCodes, with at most concurrency chunks in flight at once, or
+// DefaultItemConcurrency if concurrency is not positive. Requests still go
+// through the client's rate limiter. The works are returned in the order of
+// the groups and summaries in the works response.
+//
+// The result may be partial: works ORCID reports as unavailable, and those
+// in chunks whose request failed, are left out, and the returned error joins
+// the reasons. Errors that would fail every request, ErrUnauthorized or the
+// client being closed, cancel the outstanding chunks, as does ctx being
+// done; other chunk failures do not affect the remaining chunks.
+func (c *Client) GetAllWorkDetails(ctx context.Context, orcidID string, concurrency int) ([]*Work, error) {
+	if concurrency <= 0 {
+		concurrency = DefaultItemConcurrency
+	}
+
+	works, err := c.GetWorks(ctx, orcidID)
+	if err != nil {
+		return nil, err
+	}
+
+	var putCodes []int64
+	for _, summary := range works.Summaries() {
+		putCodes = append(putCodes, summary.PutCode)
+	}
+
+	chunkCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	var (
+		mu     sync.Mutex
+		wg     sync.WaitGroup
+		byCode = make(map[int64]*Work, len(putCodes))
+		errs   []error
+		sem    = make(chan struct{}, concurrency)
+	)
+	for start := 0; start < len(putCodes); start += MaxBulkPutCodes {
+		end := min(start+MaxBulkPutCodes, len(putCodes))
+
+		wg.Add(1)
+		go func(chunk []int64) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-chunkCtx.Done():
+				return
+			}
+
+			fetched, itemErr, err := c.getWorksBulk(chunkCtx, orcidID, chunk)
+
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				// Chunks cancelled because of an earlier failure or ctx are
+				// covered by that error, which is reported once below
+				if chunkCtx.Err() != nil {
+					return
+				}
+				errs = append(errs, fmt.Errorf("put-codes %d-%d: %w", chunk[0], chunk[len(chunk)-1], err))
+				if errors.Is(err, ErrUnauthorized) || errors.Is(err, errClientClosed) {
+					cancel()
+				}
+				return
+			}
+			for _, work := range fetched {
+				byCode[work.PutCode] = work
+			}
+			if itemErr != nil {
+				errs = append(errs, itemErr)
+			}
+		}(putCodes[start:end])
+	}
+	wg.Wait()
+
+	if err := ctx.Err(); err != nil {
+		errs = append(errs, err)
+	}
+
+	result := make([]*Work, 0, len(putCodes))
+	for _, putCode := range putCodes {
+		if work, ok := byCode[putCode]; ok {
+			result = append(result, work)
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
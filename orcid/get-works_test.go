@@ -0,0 +1,350 @@
+package orcid
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetAllWorkDetails(t *testing.T) {
+	var mu sync.Mutex
+	var bulkSizes []int
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/v3.0/0000-0002-1825-0097/works" {
+			// 150 works spread over 3 groups of 50
+			var groups []string
+			for g := 0; g < 3; g++ {
+				var summaries []string
+				for i := 0; i < 50; i++ {
+					summaries = append(summaries, fmt.Sprintf(`{"put-code": %d}`, g*50+i+1))
+				}
+				groups = append(groups, fmt.Sprintf(`{"work-summary": [%s]}`, strings.Join(summaries, ",")))
+			}
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"group": [%s]}`, strings.Join(groups, ","))
+			return
+		}
+
+		codes := strings.Split(strings.TrimPrefix(r.URL.Path, "/v3.0/0000-0002-1825-0097/works/"), ",")
+		mu.Lock()
+		bulkSizes = append(bulkSizes, len(codes))
+		mu.Unlock()
+
+		var items []string
+		for _, code := range codes {
+			items = append(items, fmt.Sprintf(`{"work": {"put-code": %s, "title": {"title": {"value": "Work %s"}}}}`, code, code))
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"bulk": [%s]}`, strings.Join(items, ","))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(100),
+	)
+	ctx := context.Background()
+
+	works, err := client.GetAllWorkDetails(ctx, "0000-0002-1825-0097", 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(bulkSizes) != 2 {
+		t.Fatalf("Expected 2 bulk calls, got %d", len(bulkSizes))
+	}
+	if bulkSizes[0]+bulkSizes[1] != 150 || (bulkSizes[0] != 100 && bulkSizes[1] != 100) {
+		t.Errorf("Expected bulk calls of 100 and 50 put-codes, got %v", bulkSizes)
+	}
+	if len(works) != 150 {
+		t.Fatalf("Expected 150 works, got %d", len(works))
+	}
+	for i, work := range works {
+		if work.PutCode != int64(i+1) {
+			t.Fatalf("Expected put-code %d at index %d, got %d", i+1, i, work.PutCode)
+		}
+		if work.Title == nil || work.Title.Title.Value != fmt.Sprintf("Work %d", i+1) {
+			t.Errorf("Expected title for put-code %d", i+1)
+		}
+	}
+}
+
+func TestWorkBulkXML(t *testing.T) {
+	client := NewClient(WithContentType(ContentTypeXML))
+	data := []byte(`<?xml version="1.0" encoding="UTF-8"?>
+<bulk:bulk xmlns:bulk="http://www.orcid.org/ns/bulk" xmlns:work="http://www.orcid.org/ns/work" xmlns:error="http://www.orcid.org/ns/error">
+	<work:work put-code="1"><work:type>book</work:type></work:work>
+	<error:error><error:response-code>404</error:response-code><error:error-code>9016</error:error-code></error:error>
+</bulk:bulk>`)
+
+	var bulk WorkBulk
+	if err := client.decodeResponse(bytes.NewReader(data), &bulk); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(bulk.Bulk) != 2 {
+		t.Fatalf("Expected 2 bulk items, got %d", len(bulk.Bulk))
+	}
+	if bulk.Bulk[0].Work == nil || bulk.Bulk[0].Work.PutCode != 1 || bulk.Bulk[0].Work.Type != "book" {
+		t.Errorf("Expected work with put-code 1, got %+v", bulk.Bulk[0].Work)
+	}
+	if bulk.Bulk[1].Error == nil || bulk.Bulk[1].Error.ErrorCode != 9016 {
+		t.Errorf("Expected error with code 9016, got %+v", bulk.Bulk[1].Error)
+	}
+}
+
+// blockingWorksServer lists 250 works and answers bulk requests through
+// bulk, tracking how many bulk handlers are still running.
+func blockingWorksServer(active *atomic.Int32, bulk func(w http.ResponseWriter, r *http.Request)) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.URL.Path == "/v3.0/0000-0002-1825-0097/works" {
+			var summaries []string
+			for i := 1; i <= 250; i++ {
+				summaries = append(summaries, fmt.Sprintf(`{"put-code": %d}`, i))
+			}
+			w.WriteHeader(http.StatusOK)
+			fmt.Fprintf(w, `{"group": [{"work-summary": [%s]}]}`, strings.Join(summaries, ","))
+			return
+		}
+
+		active.Add(1)
+		defer active.Add(-1)
+		bulk(w, r)
+	}))
+}
+
+func waitForIdle(t *testing.T, active *atomic.Int32) {
+	deadline := time.Now().Add(2 * time.Second)
+	for active.Load() != 0 {
+		if time.Now().After(deadline) {
+			t.Fatalf("Expected all bulk requests to be cancelled, %d still running", active.Load())
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+}
+
+func TestGetAllWorkDetailsDeadline(t *testing.T) {
+	var active atomic.Int32
+	server := blockingWorksServer(&active, func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+			t.Error("Expected bulk request to be cancelled")
+		}
+	})
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(100),
+	)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 300*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	works, err := client.GetAllWorkDetails(ctx, "0000-0002-1825-0097", 0)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Fatalf("Expected context.DeadlineExceeded, got %v", err)
+	}
+	if len(works) != 0 {
+		t.Errorf("Expected no works, got %d", len(works))
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected prompt return after the deadline, took %v", elapsed)
+	}
+	waitForIdle(t, &active)
+}
+
+func TestGetAllWorkDetailsChunkError(t *testing.T) {
+	server := blockingWorksServer(new(atomic.Int32), func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v3.0/0000-0002-1825-0097/works/101,") {
+			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte(`{"error-code": 9006}`))
+			return
+		}
+		codes := strings.Split(strings.TrimPrefix(r.URL.Path, "/v3.0/0000-0002-1825-0097/works/"), ",")
+		var items []string
+		for _, code := range codes {
+			items = append(items, fmt.Sprintf(`{"work": {"put-code": %s}}`, code))
+		}
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"bulk": [%s]}`, strings.Join(items, ","))
+	})
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(100),
+	)
+
+	works, err := client.GetAllWorkDetails(context.Background(), "0000-0002-1825-0097", 0)
+	if err == nil || !strings.Contains(err.Error(), "HTTP 400") {
+		t.Fatalf("Expected the HTTP 400 error, got %v", err)
+	}
+	if !strings.Contains(err.Error(), "put-codes 101-200") {
+		t.Errorf("Expected the error to name the failed put-codes, got %v", err)
+	}
+	if errors.Is(err, context.Canceled) {
+		t.Errorf("Expected the other chunks not to be cancelled, got %v", err)
+	}
+	if len(works) != 150 {
+		t.Fatalf("Expected partial result of 150 works, got %d", len(works))
+	}
+	if works[99].PutCode != 100 || works[100].PutCode != 201 {
+		t.Errorf("Expected put-codes 100 and 201 around the failed chunk, got %d and %d", works[99].PutCode, works[100].PutCode)
+	}
+}
+
+func TestGetAllWorkDetailsCancelsOnUnauthorized(t *testing.T) {
+	var active atomic.Int32
+	server := blockingWorksServer(&active, func(w http.ResponseWriter, r *http.Request) {
+		if strings.HasPrefix(r.URL.Path, "/v3.0/0000-0002-1825-0097/works/201,") {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "invalid_token"}`))
+			return
+		}
+		select {
+		case <-r.Context().Done():
+		case <-time.After(5 * time.Second):
+			t.Error("Expected bulk request to be cancelled")
+		}
+	})
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(100),
+	)
+
+	start := time.Now()
+	works, err := client.GetAllWorkDetails(context.Background(), "0000-0002-1825-0097", 0)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Fatalf("Expected ErrUnauthorized, got %v", err)
+	}
+	if errors.Is(err, context.Canceled) {
+		t.Errorf("Expected cancelled chunks not to be reported, got %v", err)
+	}
+	if len(works) != 0 {
+		t.Errorf("Expected no works, got %d", len(works))
+	}
+	if elapsed := time.Since(start); elapsed > time.Second {
+		t.Errorf("Expected prompt return after the error, took %v", elapsed)
+	}
+	waitForIdle(t, &active)
+}
+
+func TestGetWorksByPutCodes(t *testing.T) {
+	var requested [][]string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		codes := strings.Split(strings.TrimPrefix(r.URL.Path, "/v3.0/0000-0002-1825-0097/works/"), ",")
+		requested = append(requested, codes)
+
+		var items []string
+		for _, code := range codes {
+			if code == "150" {
+				items = append(items, `{"error": {"response-code": 404, "developer-message": "No entity found", "error-code": 9016}}`)
+				continue
+			}
+			items = append(items, fmt.Sprintf(`{"work": {"put-code": %s}}`, code))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"bulk": [%s]}`, strings.Join(items, ","))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(100),
+	)
+
+	putCodes := make([]int64, 250)
+	for i := range putCodes {
+		putCodes[i] = int64(i + 1)
+	}
+
+	works, err := client.GetWorksByPutCodes(context.Background(), "0000-0002-1825-0097", putCodes)
+	if err == nil || !strings.Contains(err.Error(), "9016") {
+		t.Errorf("Expected error for put-code 150, got %v", err)
+	}
+	if len(requested) != 3 || len(requested[0]) != 100 || len(requested[1]) != 100 || len(requested[2]) != 50 {
+		t.Fatalf("Expected batches of 100, 100 and 50 put-codes, got %d batches", len(requested))
+	}
+	if requested[0][0] != "1" || requested[2][49] != "250" {
+		t.Errorf("Expected put-codes in order, got %s..%s", requested[0][0], requested[2][49])
+	}
+	if len(works) != 249 {
+		t.Fatalf("Expected 249 works, got %d", len(works))
+	}
+	if works[149].PutCode != 151 {
+		t.Errorf("Expected put-code 151 after the missing work, got %d", works[149].PutCode)
+	}
+
+	works, err = client.GetWorksByPutCodes(context.Background(), "0000-0002-1825-0097", nil)
+	if err != nil || len(works) != 0 {
+		t.Errorf("Expected no works and no error for no put-codes, got %d, %v", len(works), err)
+	}
+}
+
+func TestGetAllWorkDetailsConcurrency(t *testing.T) {
+	var active, peak atomic.Int32
+	server := blockingWorksServer(&active, func(w http.ResponseWriter, r *http.Request) {
+		n := active.Load()
+		for {
+			p := peak.Load()
+			if n <= p || peak.CompareAndSwap(p, n) {
+				break
+			}
+		}
+		time.Sleep(20 * time.Millisecond)
+
+		codes := strings.Split(strings.TrimPrefix(r.URL.Path, "/v3.0/0000-0002-1825-0097/works/"), ",")
+		var items []string
+		for _, code := range codes {
+			if code == "7" {
+				items = append(items, `{"error": {"response-code": 404, "error-code": 9016}}`)
+				continue
+			}
+			items = append(items, fmt.Sprintf(`{"work": {"put-code": %s}}`, code))
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		fmt.Fprintf(w, `{"bulk": [%s]}`, strings.Join(items, ","))
+	})
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(100),
+	)
+
+	works, err := client.GetAllWorkDetails(context.Background(), "0000-0002-1825-0097", 1)
+	if err == nil || !strings.Contains(err.Error(), "9016") {
+		t.Errorf("Expected error for the missing work, got %v", err)
+	}
+	if len(works) != 249 {
+		t.Errorf("Expected partial result of 249 works, got %d", len(works))
+	}
+	if p := peak.Load(); p != 1 {
+		t.Errorf("Expected at most 1 concurrent bulk request, got %d", p)
+	}
+}
@@ -0,0 +1,63 @@
+package orcid
+
+import (
+	"log/slog"
+	"strings"
+	"time"
+)
+
+// RequestInfo describes an HTTP attempt about to be sent.
+type RequestInfo struct {
+	Method string
+	URL    string
+	// Attempt is 1 for the first try and increases with each retry.
+	Attempt int
+}
+
+// ResponseInfo describes the outcome of an HTTP attempt. StatusCode is zero
+// and Err is set when no response was received.
+type ResponseInfo struct {
+	Method string
+	URL    string
+	// FinalURL is the URL that answered, which differs from URL when
+	// redirects were followed. It is empty when no response was received.
+	FinalURL   string
+	StatusCode int
+	Duration   time.Duration
+	Attempt    int
+	Err        error
+}
+
+// WithRequestHook registers a function called before every HTTP attempt,
+// retries included. It runs on the goroutine making the request and should
+// return quickly.
+func WithRequestHook(hook func(RequestInfo)) ClientOption {
+	return func(c *Client) {
+		c.requestHook = hook
+	}
+}
+
+// WithResponseHook registers a function called after every HTTP attempt,
+// retries included, whether or not a response was received.
+func WithResponseHook(hook func(ResponseInfo)) ClientOption {
+	return func(c *Client) {
+		c.responseHook = hook
+	}
+}
+
+// WithLogger logs every HTTP attempt to logger at debug level, with the
+// ORCID iD, endpoint, status and retry number, and every retry at warn level
+// together with the backoff and the error that caused it.
+func WithLogger(logger *slog.Logger) ClientOption {
+	return func(c *Client) {
+		c.logger = logger
+	}
+}
+
+// endpoint returns url relative to the client's API URL, for logging.
+func (c *Client) endpoint(url string) string {
+	if path := strings.TrimPrefix(url, c.apiURL); path != url {
+		return path
+	}
+	return url
+}
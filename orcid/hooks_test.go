@@ -0,0 +1,155 @@
+package orcid
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRequestAndResponseHooks(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var requestInfos []RequestInfo
+	var responseInfos []ResponseInfo
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(1000),
+		WithBackoff(func(int) time.Duration { return time.Millisecond }),
+		WithRequestHook(func(info RequestInfo) { requestInfos = append(requestInfos, info) }),
+		WithResponseHook(func(info ResponseInfo) { responseInfos = append(responseInfos, info) }),
+	)
+
+	if _, err := client.GetPerson(context.Background(), "0000-0002-1825-0097"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	url := server.URL + "/v3.0/0000-0002-1825-0097/person"
+	if len(requestInfos) != 2 || len(responseInfos) != 2 {
+		t.Fatalf("Expected hooks to fire for 2 attempts, got %d requests and %d responses", len(requestInfos), len(responseInfos))
+	}
+	for i, info := range requestInfos {
+		if info.Method != http.MethodGet || info.URL != url || info.Attempt != i+1 {
+			t.Errorf("Unexpected request info for attempt %d: %+v", i+1, info)
+		}
+	}
+	for i, status := range []int{http.StatusServiceUnavailable, http.StatusOK} {
+		info := responseInfos[i]
+		if info.Method != http.MethodGet || info.URL != url || info.Attempt != i+1 {
+			t.Errorf("Unexpected response info for attempt %d: %+v", i+1, info)
+		}
+		if info.StatusCode != status {
+			t.Errorf("Expected status %d for attempt %d, got %d", status, i+1, info.StatusCode)
+		}
+		if info.Duration <= 0 || info.Err != nil {
+			t.Errorf("Expected a duration and no error for attempt %d, got %v and %v", i+1, info.Duration, info.Err)
+		}
+	}
+}
+
+func TestResponseHookNetworkError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	serverURL := server.URL
+	server.Close()
+
+	var responseInfos []ResponseInfo
+	client := NewClient(
+		WithAPIURL(serverURL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithMaxRetries(0),
+		WithResponseHook(func(info ResponseInfo) { responseInfos = append(responseInfos, info) }),
+	)
+
+	if _, err := client.GetPerson(context.Background(), "0000-0002-1825-0097"); err == nil {
+		t.Fatal("Expected error")
+	}
+	if len(responseInfos) != 1 {
+		t.Fatalf("Expected 1 response hook call, got %d", len(responseInfos))
+	}
+	if responseInfos[0].Err == nil || responseInfos[0].StatusCode != 0 {
+		t.Errorf("Expected an error and no status, got %+v", responseInfos[0])
+	}
+}
+
+func TestWithLogger(t *testing.T) {
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if requests == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	var buf bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&buf, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(1000),
+		WithBackoff(func(int) time.Duration { return time.Millisecond }),
+		WithLogger(logger),
+	)
+
+	if _, err := client.GetPerson(context.Background(), "0000-0002-1825-0097"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	var entries []map[string]interface{}
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		var entry map[string]interface{}
+		if err := json.Unmarshal([]byte(line), &entry); err != nil {
+			t.Fatalf("Invalid log line %q: %v", line, err)
+		}
+		entries = append(entries, entry)
+	}
+
+	if len(entries) != 3 {
+		t.Fatalf("Expected 3 log entries, got %d: %s", len(entries), buf.String())
+	}
+	expected := []struct {
+		level  string
+		status float64
+		retry  float64
+	}{
+		{"DEBUG", http.StatusServiceUnavailable, 0},
+		{"WARN", 0, 1},
+		{"DEBUG", http.StatusOK, 1},
+	}
+	for i, want := range expected {
+		entry := entries[i]
+		if entry["level"] != want.level {
+			t.Errorf("Expected level %s for entry %d, got %v", want.level, i, entry["level"])
+		}
+		if entry["orcid"] != "0000-0002-1825-0097" || entry["endpoint"] != "/0000-0002-1825-0097/person" {
+			t.Errorf("Expected iD and endpoint in entry %d, got %v", i, entry)
+		}
+		if entry["retry"] != want.retry {
+			t.Errorf("Expected retry %v for entry %d, got %v", want.retry, i, entry["retry"])
+		}
+		if want.status != 0 && entry["status"] != want.status {
+			t.Errorf("Expected status %v for entry %d, got %v", want.status, i, entry["status"])
+		}
+	}
+}
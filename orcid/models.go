@@ -2,12 +2,82 @@ package orcid
 
 import (
 	"encoding/json"
+	"encoding/xml"
+	"strconv"
 	"time"
 )
 
 // Path is a type alias for ORCID API paths
 type Path string
 
+// Visibility is the visibility level of an item on an ORCID record.
+type Visibility string
+
+const (
+	VisibilityPublic  Visibility = "public"
+	VisibilityLimited Visibility = "limited"
+	VisibilityPrivate Visibility = "private"
+)
+
+// WorkType is the type of a work, as defined by ORCID.
+type WorkType string
+
+const (
+	WorkTypeAnnotation                   WorkType = "annotation"
+	WorkTypeArtisticPerformance          WorkType = "artistic-performance"
+	WorkTypeBook                         WorkType = "book"
+	WorkTypeBookChapter                  WorkType = "book-chapter"
+	WorkTypeBookReview                   WorkType = "book-review"
+	WorkTypeCartographicMaterial         WorkType = "cartographic-material"
+	WorkTypeClinicalStudy                WorkType = "clinical-study"
+	WorkTypeConferenceAbstract           WorkType = "conference-abstract"
+	WorkTypeConferencePaper              WorkType = "conference-paper"
+	WorkTypeConferencePoster             WorkType = "conference-poster"
+	WorkTypeDataManagementPlan           WorkType = "data-management-plan"
+	WorkTypeDataSet                      WorkType = "data-set"
+	WorkTypeDesign                       WorkType = "design"
+	WorkTypeDictionaryEntry              WorkType = "dictionary-entry"
+	WorkTypeDisclosure                   WorkType = "disclosure"
+	WorkTypeDissertationThesis           WorkType = "dissertation-thesis"
+	WorkTypeEditedBook                   WorkType = "edited-book"
+	WorkTypeEncyclopediaEntry            WorkType = "encyclopedia-entry"
+	WorkTypeImage                        WorkType = "image"
+	WorkTypeInvention                    WorkType = "invention"
+	WorkTypeJournalArticle               WorkType = "journal-article"
+	WorkTypeJournalIssue                 WorkType = "journal-issue"
+	WorkTypeLearningObject               WorkType = "learning-object"
+	WorkTypeLectureSpeech                WorkType = "lecture-speech"
+	WorkTypeLicense                      WorkType = "license"
+	WorkTypeMagazineArticle              WorkType = "magazine-article"
+	WorkTypeManual                       WorkType = "manual"
+	WorkTypeMovingImage                  WorkType = "moving-image"
+	WorkTypeMusicalComposition           WorkType = "musical-composition"
+	WorkTypeNewsletterArticle            WorkType = "newsletter-article"
+	WorkTypeNewspaperArticle             WorkType = "newspaper-article"
+	WorkTypeOnlineResource               WorkType = "online-resource"
+	WorkTypeOther                        WorkType = "other"
+	WorkTypePatent                       WorkType = "patent"
+	WorkTypePhysicalObject               WorkType = "physical-object"
+	WorkTypePreprint                     WorkType = "preprint"
+	WorkTypePublicSpeech                 WorkType = "public-speech"
+	WorkTypeRegisteredCopyright          WorkType = "registered-copyright"
+	WorkTypeReport                       WorkType = "report"
+	WorkTypeResearchTechnique            WorkType = "research-technique"
+	WorkTypeResearchTool                 WorkType = "research-tool"
+	WorkTypeReview                       WorkType = "review"
+	WorkTypeSoftware                     WorkType = "software"
+	WorkTypeSound                        WorkType = "sound"
+	WorkTypeSpinOffCompany               WorkType = "spin-off-company"
+	WorkTypeStandardsAndPolicy           WorkType = "standards-and-policy"
+	WorkTypeSupervisedStudentPublication WorkType = "supervised-student-publication"
+	WorkTypeTechnicalStandard            WorkType = "technical-standard"
+	WorkTypeTest                         WorkType = "test"
+	WorkTypeTrademark                    WorkType = "trademark"
+	WorkTypeTranslation                  WorkType = "translation"
+	WorkTypeWebsite                      WorkType = "website"
+	WorkTypeWorkingPaper                 WorkType = "working-paper"
+)
+
 type Record struct {
 	OrcidIdentifier   *OrcidIdentifier   `json:"orcid-identifier,omitempty" xml:"orcid-identifier,omitempty"`
 	Preferences       *Preferences       `json:"preferences,omitempty" xml:"preferences,omitempty"`
@@ -110,7 +180,7 @@ type Name struct {
 	FamilyName       *FamilyName `json:"family-name,omitempty" xml:"family-name,omitempty"`
 	CreditName       *CreditName `json:"credit-name,omitempty" xml:"credit-name,omitempty"`
 	Source           *Source     `json:"source,omitempty" xml:"source,omitempty"`
-	Visibility       string      `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	Visibility       Visibility  `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
 }
 
@@ -133,21 +203,21 @@ type OtherNames struct {
 }
 
 type OtherName struct {
-	CreatedDate      *Date   `json:"created-date,omitempty" xml:"created-date,omitempty"`
-	LastModifiedDate *Date   `json:"last-modified-date,omitempty" xml:"last-modified-date,omitempty"`
-	Source           *Source `json:"source,omitempty" xml:"source,omitempty"`
-	Content          string  `json:"content,omitempty" xml:"content,omitempty"`
-	Visibility       string  `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	CreatedDate      *Date      `json:"created-date,omitempty" xml:"created-date,omitempty"`
+	LastModifiedDate *Date      `json:"last-modified-date,omitempty" xml:"last-modified-date,omitempty"`
+	Source           *Source    `json:"source,omitempty" xml:"source,omitempty"`
+	Content          string     `json:"content,omitempty" xml:"content,omitempty"`
+	Visibility       Visibility `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
-	PutCode          int64   `json:"put-code,omitempty" xml:"put-code,attr,omitempty"`
-	DisplayIndex     int     `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
+	PutCode          int64      `json:"put-code,omitempty" xml:"put-code,attr,omitempty"`
+	DisplayIndex     int        `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
 }
 
 type Biography struct {
-	CreatedDate      *Date  `json:"created-date,omitempty" xml:"created-date,omitempty"`
-	LastModifiedDate *Date  `json:"last-modified-date,omitempty" xml:"last-modified-date,omitempty"`
-	Content          string `json:"content,omitempty" xml:"content,omitempty"`
-	Visibility       string `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	CreatedDate      *Date      `json:"created-date,omitempty" xml:"created-date,omitempty"`
+	LastModifiedDate *Date      `json:"last-modified-date,omitempty" xml:"last-modified-date,omitempty"`
+	Content          string     `json:"content,omitempty" xml:"content,omitempty"`
+	Visibility       Visibility `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
 }
 
@@ -158,15 +228,15 @@ type ResearcherURLs struct {
 }
 
 type ResearcherURL struct {
-	CreatedDate      *Date   `json:"created-date,omitempty" xml:"created-date,omitempty"`
-	LastModifiedDate *Date   `json:"last-modified-date,omitempty" xml:"last-modified-date,omitempty"`
-	Source           *Source `json:"source,omitempty" xml:"source,omitempty"`
-	URLName          string  `json:"url-name,omitempty" xml:"url-name,omitempty"`
-	URL              *URL    `json:"url,omitempty" xml:"url,omitempty"`
-	Visibility       string  `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	CreatedDate      *Date      `json:"created-date,omitempty" xml:"created-date,omitempty"`
+	LastModifiedDate *Date      `json:"last-modified-date,omitempty" xml:"last-modified-date,omitempty"`
+	Source           *Source    `json:"source,omitempty" xml:"source,omitempty"`
+	URLName          string     `json:"url-name,omitempty" xml:"url-name,omitempty"`
+	URL              *URL       `json:"url,omitempty" xml:"url,omitempty"`
+	Visibility       Visibility `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
-	PutCode          int64   `json:"put-code,omitempty" xml:"put-code,attr,omitempty"`
-	DisplayIndex     int     `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
+	PutCode          int64      `json:"put-code,omitempty" xml:"put-code,attr,omitempty"`
+	DisplayIndex     int        `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
 }
 
 type URL struct {
@@ -180,15 +250,15 @@ type Emails struct {
 }
 
 type Email struct {
-	CreatedDate      *Date   `json:"created-date,omitempty" xml:"created-date,omitempty"`
-	LastModifiedDate *Date   `json:"last-modified-date,omitempty" xml:"last-modified-date,omitempty"`
-	Source           *Source `json:"source,omitempty" xml:"source,omitempty"`
-	Email            string  `json:"email,omitempty" xml:"email,omitempty"`
-	Primary          bool    `json:"primary,omitempty" xml:"primary,omitempty"`
-	Verified         bool    `json:"verified,omitempty" xml:"verified,omitempty"`
-	Visibility       string  `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	CreatedDate      *Date      `json:"created-date,omitempty" xml:"created-date,omitempty"`
+	LastModifiedDate *Date      `json:"last-modified-date,omitempty" xml:"last-modified-date,omitempty"`
+	Source           *Source    `json:"source,omitempty" xml:"source,omitempty"`
+	Email            string     `json:"email,omitempty" xml:"email,omitempty"`
+	Primary          bool       `json:"primary,omitempty" xml:"primary,omitempty"`
+	Verified         bool       `json:"verified,omitempty" xml:"verified,omitempty"`
+	Visibility       Visibility `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
-	PutCode          int64   `json:"put-code,omitempty" xml:"put-code,attr,omitempty"`
+	PutCode          int64      `json:"put-code,omitempty" xml:"put-code,attr,omitempty"`
 }
 
 type Addresses struct {
@@ -198,14 +268,14 @@ type Addresses struct {
 }
 
 type Address struct {
-	CreatedDate      *Date    `json:"created-date,omitempty" xml:"created-date,omitempty"`
-	LastModifiedDate *Date    `json:"last-modified-date,omitempty" xml:"last-modified-date,omitempty"`
-	Source           *Source  `json:"source,omitempty" xml:"source,omitempty"`
-	Country          *Country `json:"country,omitempty" xml:"country,omitempty"`
-	Visibility       string   `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	CreatedDate      *Date      `json:"created-date,omitempty" xml:"created-date,omitempty"`
+	LastModifiedDate *Date      `json:"last-modified-date,omitempty" xml:"last-modified-date,omitempty"`
+	Source           *Source    `json:"source,omitempty" xml:"source,omitempty"`
+	Country          *Country   `json:"country,omitempty" xml:"country,omitempty"`
+	Visibility       Visibility `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
-	PutCode          int64    `json:"put-code,omitempty" xml:"put-code,attr,omitempty"`
-	DisplayIndex     int      `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
+	PutCode          int64      `json:"put-code,omitempty" xml:"put-code,attr,omitempty"`
+	DisplayIndex     int        `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
 }
 
 type Country struct {
@@ -219,14 +289,14 @@ type Keywords struct {
 }
 
 type Keyword struct {
-	CreatedDate      *Date   `json:"created-date,omitempty" xml:"created-date,omitempty"`
-	LastModifiedDate *Date   `json:"last-modified-date,omitempty" xml:"last-modified-date,omitempty"`
-	Source           *Source `json:"source,omitempty" xml:"source,omitempty"`
-	Content          string  `json:"content,omitempty" xml:"content,omitempty"`
-	Visibility       string  `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	CreatedDate      *Date      `json:"created-date,omitempty" xml:"created-date,omitempty"`
+	LastModifiedDate *Date      `json:"last-modified-date,omitempty" xml:"last-modified-date,omitempty"`
+	Source           *Source    `json:"source,omitempty" xml:"source,omitempty"`
+	Content          string     `json:"content,omitempty" xml:"content,omitempty"`
+	Visibility       Visibility `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
-	PutCode          int64   `json:"put-code,omitempty" xml:"put-code,attr,omitempty"`
-	DisplayIndex     int     `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
+	PutCode          int64      `json:"put-code,omitempty" xml:"put-code,attr,omitempty"`
+	DisplayIndex     int        `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
 }
 
 type ExternalIdentifiers struct {
@@ -236,17 +306,17 @@ type ExternalIdentifiers struct {
 }
 
 type ExternalIdentifier struct {
-	CreatedDate                    *Date   `json:"created-date,omitempty" xml:"created-date,omitempty"`
-	LastModifiedDate               *Date   `json:"last-modified-date,omitempty" xml:"last-modified-date,omitempty"`
-	Source                         *Source `json:"source,omitempty" xml:"source,omitempty"`
-	ExternalIdentifierType         string  `json:"external-id-type,omitempty" xml:"external-id-type,omitempty"`
-	ExternalIdentifierValue        string  `json:"external-id-value,omitempty" xml:"external-id-value,omitempty"`
-	ExternalIdentifierURL          *URL    `json:"external-id-url,omitempty" xml:"external-id-url,omitempty"`
-	ExternalIdentifierRelationship string  `json:"external-id-relationship,omitempty" xml:"external-id-relationship,omitempty"`
-	Visibility                     string  `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	CreatedDate                    *Date      `json:"created-date,omitempty" xml:"created-date,omitempty"`
+	LastModifiedDate               *Date      `json:"last-modified-date,omitempty" xml:"last-modified-date,omitempty"`
+	Source                         *Source    `json:"source,omitempty" xml:"source,omitempty"`
+	ExternalIdentifierType         string     `json:"external-id-type,omitempty" xml:"external-id-type,omitempty"`
+	ExternalIdentifierValue        string     `json:"external-id-value,omitempty" xml:"external-id-value,omitempty"`
+	ExternalIdentifierURL          *URL       `json:"external-id-url,omitempty" xml:"external-id-url,omitempty"`
+	ExternalIdentifierRelationship string     `json:"external-id-relationship,omitempty" xml:"external-id-relationship,omitempty"`
+	Visibility                     Visibility `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
-	PutCode                        int64   `json:"put-code,omitempty" xml:"put-code,attr,omitempty"`
-	DisplayIndex                   int     `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
+	PutCode                        int64      `json:"put-code,omitempty" xml:"put-code,attr,omitempty"`
+	DisplayIndex                   int        `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
 }
 
 type ActivitiesSummary struct {
@@ -301,10 +371,10 @@ type WorkSummary struct {
 	Source           *Source          `json:"source,omitempty" xml:"source,omitempty"`
 	Title            *Title           `json:"title,omitempty" xml:"title,omitempty"`
 	ExternalIDs      *ExternalIDs     `json:"external-ids,omitempty" xml:"external-ids,omitempty"`
-	Type             string           `json:"type,omitempty" xml:"type,omitempty"`
+	Type             WorkType         `json:"type,omitempty" xml:"type,omitempty"`
 	PublicationDate  *PublicationDate `json:"publication-date,omitempty" xml:"publication-date,omitempty"`
 	JournalTitle     JournalTitle     `json:"journal-title,omitempty" xml:"journal-title,omitempty"`
-	Visibility       string           `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	Visibility       Visibility       `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
 	DisplayIndex     string           `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
 }
@@ -324,14 +394,14 @@ type Work struct {
 	JournalTitle     JournalTitle     `json:"journal-title,omitempty" xml:"journal-title,omitempty"`
 	ShortDescription string           `json:"short-description,omitempty" xml:"short-description,omitempty"`
 	Citation         *Citation        `json:"citation,omitempty" xml:"citation,omitempty"`
-	Type             string           `json:"type,omitempty" xml:"type,omitempty"`
+	Type             WorkType         `json:"type,omitempty" xml:"type,omitempty"`
 	PublicationDate  *PublicationDate `json:"publication-date,omitempty" xml:"publication-date,omitempty"`
 	ExternalIDs      *ExternalIDs     `json:"external-ids,omitempty" xml:"external-ids,omitempty"`
 	URL              *URL             `json:"url,omitempty" xml:"url,omitempty"`
 	Contributors     *Contributors    `json:"contributors,omitempty" xml:"contributors,omitempty"`
 	LanguageCode     string           `json:"language-code,omitempty" xml:"language-code,omitempty"`
 	Country          *Country         `json:"country,omitempty" xml:"country,omitempty"`
-	Visibility       string           `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	Visibility       Visibility       `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
 }
 
@@ -419,10 +489,14 @@ type EducationSummary struct {
 	URL              *URL          `json:"url,omitempty" xml:"url,omitempty"`
 	ExternalIDs      *ExternalIDs  `json:"external-ids,omitempty" xml:"external-ids,omitempty"`
 	DisplayIndex     string        `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
-	Visibility       string        `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	Visibility       Visibility    `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
 }
 
+// AffiliationGroup holds the versions of one affiliation recorded by
+// different sources. Summaries holds typed summaries such as
+// *EducationSummary; unknown kinds are kept as maps in JSON and skipped in
+// XML.
 type AffiliationGroup struct {
 	LastModifiedDate *Date         `json:"last-modified-date,omitempty" xml:"last-modified-date,omitempty"`
 	ExternalIDs      *ExternalIDs  `json:"external-ids,omitempty" xml:"external-ids,omitempty"`
@@ -472,7 +546,7 @@ type EmploymentSummary struct {
 	URL              *URL          `json:"url,omitempty" xml:"url,omitempty"`
 	ExternalIDs      *ExternalIDs  `json:"external-ids,omitempty" xml:"external-ids,omitempty"`
 	DisplayIndex     string        `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
-	Visibility       string        `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	Visibility       Visibility    `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
 }
 
@@ -501,7 +575,7 @@ type FundingSummary struct {
 	URL              *URL          `json:"url,omitempty" xml:"url,omitempty"`
 	ExternalIDs      *ExternalIDs  `json:"external-ids,omitempty" xml:"external-ids,omitempty"`
 	DisplayIndex     string        `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
-	Visibility       string        `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	Visibility       Visibility    `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
 }
 
@@ -529,7 +603,7 @@ type PeerReviewSummary struct {
 	Organization         *Organization `json:"convening-organization,omitempty" xml:"convening-organization,omitempty"`
 	ExternalIDs          *ExternalIDs  `json:"external-ids,omitempty" xml:"external-ids,omitempty"`
 	DisplayIndex         string        `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
-	Visibility           string        `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	Visibility           Visibility    `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
 }
 
@@ -553,7 +627,7 @@ type DistinctionSummary struct {
 	URL              *URL          `json:"url,omitempty" xml:"url,omitempty"`
 	ExternalIDs      *ExternalIDs  `json:"external-ids,omitempty" xml:"external-ids,omitempty"`
 	DisplayIndex     string        `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
-	Visibility       string        `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	Visibility       Visibility    `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
 }
 
@@ -577,7 +651,7 @@ type InvitedPositionSummary struct {
 	URL              *URL          `json:"url,omitempty" xml:"url,omitempty"`
 	ExternalIDs      *ExternalIDs  `json:"external-ids,omitempty" xml:"external-ids,omitempty"`
 	DisplayIndex     string        `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
-	Visibility       string        `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	Visibility       Visibility    `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
 }
 
@@ -601,7 +675,7 @@ type MembershipSummary struct {
 	URL              *URL          `json:"url,omitempty" xml:"url,omitempty"`
 	ExternalIDs      *ExternalIDs  `json:"external-ids,omitempty" xml:"external-ids,omitempty"`
 	DisplayIndex     string        `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
-	Visibility       string        `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	Visibility       Visibility    `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
 }
 
@@ -625,7 +699,7 @@ type QualificationSummary struct {
 	URL              *URL          `json:"url,omitempty" xml:"url,omitempty"`
 	ExternalIDs      *ExternalIDs  `json:"external-ids,omitempty" xml:"external-ids,omitempty"`
 	DisplayIndex     string        `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
-	Visibility       string        `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	Visibility       Visibility    `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
 }
 
@@ -649,7 +723,7 @@ type ServiceSummary struct {
 	URL              *URL          `json:"url,omitempty" xml:"url,omitempty"`
 	ExternalIDs      *ExternalIDs  `json:"external-ids,omitempty" xml:"external-ids,omitempty"`
 	DisplayIndex     string        `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
-	Visibility       string        `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	Visibility       Visibility    `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
 }
 
@@ -673,6 +747,443 @@ type ResearchResourceSummary struct {
 	Title            string       `json:"title,omitempty" xml:"title,omitempty"`
 	ExternalIDs      *ExternalIDs `json:"external-ids,omitempty" xml:"external-ids,omitempty"`
 	DisplayIndex     string       `json:"display-index,omitempty" xml:"display-index,attr,omitempty"`
-	Visibility       string       `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
+	Visibility       Visibility   `json:"visibility,omitempty" xml:"visibility,attr,omitempty"`
 	Path Path `json:"path,omitempty" xml:"path,attr,omitempty"`
 }
+
+type OrcidError struct {
+	ResponseCode     int    `json:"response-code,omitempty" xml:"response-code,omitempty"`
+	DeveloperMessage string `json:"developer-message,omitempty" xml:"developer-message,omitempty"`
+	UserMessage      string `json:"user-message,omitempty" xml:"user-message,omitempty"`
+	ErrorCode        int    `json:"error-code,omitempty" xml:"error-code,omitempty"`
+	MoreInfo         string `json:"more-info,omitempty" xml:"more-info,omitempty"`
+}
+
+// WorkBulk is the response of the bulk works endpoint. Each item holds either
+// a work or the error returned for the corresponding put-code.
+type WorkBulk struct {
+	Bulk []*WorkBulkItem `json:"bulk,omitempty" xml:"bulk,omitempty"`
+}
+
+type WorkBulkItem struct {
+	Work  *Work       `json:"work,omitempty" xml:"work,omitempty"`
+	Error *OrcidError `json:"error,omitempty" xml:"error,omitempty"`
+}
+
+// UnmarshalXML handles the XML form of the bulk response, where works and
+// errors are direct children of the bulk element rather than wrapped items
+func (b *WorkBulk) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			item := &WorkBulkItem{}
+			switch t.Name.Local {
+			case "work":
+				item.Work = &Work{}
+				err = d.DecodeElement(item.Work, &t)
+			case "error":
+				item.Error = &OrcidError{}
+				err = d.DecodeElement(item.Error, &t)
+			default:
+				err = d.Skip()
+				item = nil
+			}
+			if err != nil {
+				return err
+			}
+			if item != nil {
+				b.Bulk = append(b.Bulk, item)
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// MarshalXML writes the bulk element in the form used by the bulk works
+// endpoint, with each work or error as a direct child.
+func (b *WorkBulk) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	start = xml.StartElement{Name: xml.Name{Local: "bulk"}}
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	for _, item := range b.Bulk {
+		var err error
+		switch {
+		case item == nil:
+		case item.Work != nil:
+			err = e.EncodeElement(item.Work, xml.StartElement{Name: xml.Name{Local: "work"}})
+		case item.Error != nil:
+			err = e.EncodeElement(item.Error, xml.StartElement{Name: xml.Name{Local: "error"}})
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// affiliationSummaryTypes maps the key wrapping each summary inside an
+// affiliation group to a constructor for its typed summary.
+var affiliationSummaryTypes = map[string]func() interface{}{
+	"education-summary":        func() interface{} { return &EducationSummary{} },
+	"employment-summary":       func() interface{} { return &EmploymentSummary{} },
+	"distinction-summary":      func() interface{} { return &DistinctionSummary{} },
+	"invited-position-summary": func() interface{} { return &InvitedPositionSummary{} },
+	"membership-summary":       func() interface{} { return &MembershipSummary{} },
+	"qualification-summary":    func() interface{} { return &QualificationSummary{} },
+	"service-summary":          func() interface{} { return &ServiceSummary{} },
+}
+
+// UnmarshalJSON decodes each entry of Summaries into its typed summary, such
+// as *EducationSummary, instead of a generic map. Entries of an unknown type
+// are kept as map[string]interface{}.
+func (g *AffiliationGroup) UnmarshalJSON(data []byte) error {
+	var raw struct {
+		LastModifiedDate *Date                        `json:"last-modified-date"`
+		ExternalIDs      *ExternalIDs                 `json:"external-ids"`
+		Summaries        []map[string]json.RawMessage `json:"summaries"`
+	}
+	if err := json.Unmarshal(data, &raw); err != nil {
+		return err
+	}
+
+	g.LastModifiedDate = raw.LastModifiedDate
+	g.ExternalIDs = raw.ExternalIDs
+	g.Summaries = nil
+	for _, entry := range raw.Summaries {
+		var summary interface{}
+		if len(entry) == 1 {
+			for key, value := range entry {
+				if newSummary, ok := affiliationSummaryTypes[key]; ok {
+					summary = newSummary()
+					if err := json.Unmarshal(value, summary); err != nil {
+						return err
+					}
+				}
+			}
+		}
+		if summary == nil {
+			generic := make(map[string]interface{}, len(entry))
+			for key, value := range entry {
+				var v interface{}
+				if err := json.Unmarshal(value, &v); err != nil {
+					return err
+				}
+				generic[key] = v
+			}
+			summary = generic
+		}
+		g.Summaries = append(g.Summaries, summary)
+	}
+	return nil
+}
+
+// affiliationSummaryKey returns the key wrapping summary inside an
+// affiliation group, or an empty string for a summary of unknown type.
+func affiliationSummaryKey(summary interface{}) string {
+	switch summary.(type) {
+	case *EducationSummary:
+		return "education-summary"
+	case *EmploymentSummary:
+		return "employment-summary"
+	case *DistinctionSummary:
+		return "distinction-summary"
+	case *InvitedPositionSummary:
+		return "invited-position-summary"
+	case *MembershipSummary:
+		return "membership-summary"
+	case *QualificationSummary:
+		return "qualification-summary"
+	case *ServiceSummary:
+		return "service-summary"
+	}
+	return ""
+}
+
+// MarshalJSON wraps each typed summary in its key again, as ORCID does, so
+// that a decoded group encodes to the same form.
+func (g *AffiliationGroup) MarshalJSON() ([]byte, error) {
+	group := struct {
+		LastModifiedDate *Date         `json:"last-modified-date,omitempty"`
+		ExternalIDs      *ExternalIDs  `json:"external-ids,omitempty"`
+		Summaries        []interface{} `json:"summaries,omitempty"`
+	}{LastModifiedDate: g.LastModifiedDate, ExternalIDs: g.ExternalIDs}
+	for _, summary := range g.Summaries {
+		if key := affiliationSummaryKey(summary); key != "" {
+			summary = map[string]interface{}{key: summary}
+		}
+		group.Summaries = append(group.Summaries, summary)
+	}
+	return json.Marshal(group)
+}
+
+// UnmarshalXML handles the XML form of an affiliation group, where each
+// summary is a direct child element rather than wrapped in "summaries".
+// Summaries of an unknown kind are skipped.
+func (g *AffiliationGroup) UnmarshalXML(d *xml.Decoder, start xml.StartElement) error {
+	*g = AffiliationGroup{}
+	for {
+		token, err := d.Token()
+		if err != nil {
+			return err
+		}
+
+		switch t := token.(type) {
+		case xml.StartElement:
+			switch t.Name.Local {
+			case "last-modified-date":
+				g.LastModifiedDate = &Date{}
+				err = d.DecodeElement(g.LastModifiedDate, &t)
+			case "external-ids":
+				g.ExternalIDs = &ExternalIDs{}
+				err = d.DecodeElement(g.ExternalIDs, &t)
+			default:
+				newSummary, ok := affiliationSummaryTypes[t.Name.Local]
+				if !ok {
+					err = d.Skip()
+					break
+				}
+				summary := newSummary()
+				if err = d.DecodeElement(summary, &t); err == nil {
+					g.Summaries = append(g.Summaries, summary)
+				}
+			}
+			if err != nil {
+				return err
+			}
+		case xml.EndElement:
+			return nil
+		}
+	}
+}
+
+// MarshalXML writes each typed summary as a direct child of the group, the
+// form UnmarshalXML reads. Summaries of an unknown kind are left out.
+func (g *AffiliationGroup) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if err := e.EncodeToken(start); err != nil {
+		return err
+	}
+	if g.LastModifiedDate != nil {
+		if err := e.EncodeElement(g.LastModifiedDate, xml.StartElement{Name: xml.Name{Local: "last-modified-date"}}); err != nil {
+			return err
+		}
+	}
+	if g.ExternalIDs != nil {
+		if err := e.EncodeElement(g.ExternalIDs, xml.StartElement{Name: xml.Name{Local: "external-ids"}}); err != nil {
+			return err
+		}
+	}
+	for _, summary := range g.Summaries {
+		key := affiliationSummaryKey(summary)
+		if key == "" {
+			continue
+		}
+		if err := e.EncodeElement(summary, xml.StartElement{Name: xml.Name{Local: key}}); err != nil {
+			return err
+		}
+	}
+	return e.EncodeToken(start.End())
+}
+
+// groupedSummaries returns the summaries listed directly followed by those
+// of type T inside groups.
+func groupedSummaries[T any](direct []*T, groups []*AffiliationGroup) []*T {
+	summaries := append([]*T(nil), direct...)
+	for _, group := range groups {
+		if group == nil {
+			continue
+		}
+		for _, item := range group.Summaries {
+			if summary, ok := item.(*T); ok {
+				summaries = append(summaries, summary)
+			}
+		}
+	}
+	return summaries
+}
+
+// Summaries returns every education summary, whether ORCID listed it directly
+// or, as in API v3.0, inside an affiliation group.
+func (e *Educations) Summaries() []*EducationSummary {
+	if e == nil {
+		return nil
+	}
+	return groupedSummaries(e.EducationSummary, e.AffiliationGroup)
+}
+
+// Summaries returns every employment summary, whether ORCID listed it
+// directly or inside an affiliation group.
+func (e *Employments) Summaries() []*EmploymentSummary {
+	if e == nil {
+		return nil
+	}
+	return groupedSummaries(e.EmploymentSummary, e.AffiliationGroup)
+}
+
+// Summaries returns every distinction summary, whether ORCID listed it
+// directly or inside an affiliation group.
+func (d *Distinctions) Summaries() []*DistinctionSummary {
+	if d == nil {
+		return nil
+	}
+	return groupedSummaries(d.DistinctionSummary, d.AffiliationGroup)
+}
+
+// Summaries returns every invited position summary, whether ORCID listed it
+// directly or inside an affiliation group.
+func (p *InvitedPositions) Summaries() []*InvitedPositionSummary {
+	if p == nil {
+		return nil
+	}
+	return groupedSummaries(p.InvitedPositionSummary, p.AffiliationGroup)
+}
+
+// Summaries returns every membership summary, whether ORCID listed it
+// directly or inside an affiliation group.
+func (m *Memberships) Summaries() []*MembershipSummary {
+	if m == nil {
+		return nil
+	}
+	return groupedSummaries(m.MembershipSummary, m.AffiliationGroup)
+}
+
+// Summaries returns every qualification summary, whether ORCID listed it
+// directly or inside an affiliation group.
+func (q *Qualifications) Summaries() []*QualificationSummary {
+	if q == nil {
+		return nil
+	}
+	return groupedSummaries(q.QualificationSummary, q.AffiliationGroup)
+}
+
+// Summaries returns every service summary, whether ORCID listed it directly
+// or inside an affiliation group.
+func (s *Services) Summaries() []*ServiceSummary {
+	if s == nil {
+		return nil
+	}
+	return groupedSummaries(s.ServiceSummary, s.AffiliationGroup)
+}
+
+// Summaries returns every work summary, flattening ORCID's groups. A work
+// recorded by several sources appears once per source; PreferredSummaries
+// returns just one version per group.
+func (w *Works) Summaries() []*WorkSummary {
+	if w == nil {
+		return nil
+	}
+	var summaries []*WorkSummary
+	for _, group := range w.WorkGroup {
+		if group == nil {
+			continue
+		}
+		for _, summary := range group.WorkSummary {
+			if summary != nil {
+				summaries = append(summaries, summary)
+			}
+		}
+	}
+	return summaries
+}
+
+// PreferredSummaries returns one work summary per group: the version the
+// owner marked as preferred, which has the highest display index, or the
+// first one listed when they are equal.
+func (w *Works) PreferredSummaries() []*WorkSummary {
+	if w == nil {
+		return nil
+	}
+	var summaries []*WorkSummary
+	for _, group := range w.WorkGroup {
+		if group == nil {
+			continue
+		}
+		var preferred *WorkSummary
+		preferredIndex := 0
+		for _, summary := range group.WorkSummary {
+			if summary == nil {
+				continue
+			}
+			index, _ := strconv.Atoi(summary.DisplayIndex)
+			if preferred == nil || index > preferredIndex {
+				preferred, preferredIndex = summary, index
+			}
+		}
+		if preferred != nil {
+			summaries = append(summaries, preferred)
+		}
+	}
+	return summaries
+}
+
+// RecordSummary is the response of the record-summary endpoint: the name,
+// identifiers and activity counts of a record without the activities
+// themselves.
+type RecordSummary struct {
+	CreatedDate            *Date                          `json:"created-date,omitempty" xml:"created-date,omitempty"`
+	LastModifiedDate       *Date                          `json:"last-modified-date,omitempty" xml:"last-modified-date,omitempty"`
+	CreditName             string                         `json:"credit-name,omitempty" xml:"credit-name,omitempty"`
+	OrcidIdentifier        string                         `json:"orcid-identifier,omitempty" xml:"orcid-identifier,omitempty"`
+	ExternalIdentifiers    *RecordSummaryIdentifiers      `json:"external-identifiers,omitempty" xml:"external-identifiers,omitempty"`
+	Employments            *RecordSummaryAffiliations     `json:"employments,omitempty" xml:"employments,omitempty"`
+	ProfessionalActivities *RecordSummaryAffiliations     `json:"professional-activities,omitempty" xml:"professional-activities,omitempty"`
+	Fundings               *RecordSummaryCounts           `json:"fundings,omitempty" xml:"fundings,omitempty"`
+	PeerReviews            *RecordSummaryPeerReviewCounts `json:"peer-reviews,omitempty" xml:"peer-reviews,omitempty"`
+	Works                  *RecordSummaryCounts           `json:"works,omitempty" xml:"works,omitempty"`
+}
+
+type RecordSummaryIdentifiers struct {
+	ExternalIdentifier []*RecordSummaryIdentifier `json:"external-identifier,omitempty" xml:"external-identifier,omitempty"`
+}
+
+type RecordSummaryIdentifier struct {
+	ExternalIDType  string `json:"external-id-type,omitempty" xml:"external-id-type,omitempty"`
+	ExternalIDValue string `json:"external-id-value,omitempty" xml:"external-id-value,omitempty"`
+	ExternalIDURL   string `json:"external-id-url,omitempty" xml:"external-id-url,omitempty"`
+	Validated       bool   `json:"validated,omitempty" xml:"validated,omitempty"`
+}
+
+// RecordSummaryAffiliations holds the count of a kind of affiliation and the
+// most recent ones.
+type RecordSummaryAffiliations struct {
+	Count       int                         `json:"count,omitempty" xml:"count,omitempty"`
+	Affiliation []*RecordSummaryAffiliation `json:"affiliation,omitempty" xml:"affiliation,omitempty"`
+}
+
+type RecordSummaryAffiliation struct {
+	PutCode          int64      `json:"put-code,omitempty" xml:"put-code,omitempty"`
+	Type             string     `json:"type,omitempty" xml:"type,omitempty"`
+	OrganizationName string     `json:"organization-name,omitempty" xml:"organization-name,omitempty"`
+	Role             string     `json:"role,omitempty" xml:"role,omitempty"`
+	URL              string     `json:"url,omitempty" xml:"url,omitempty"`
+	StartDate        *FuzzyDate `json:"start-date,omitempty" xml:"start-date,omitempty"`
+	EndDate          *FuzzyDate `json:"end-date,omitempty" xml:"end-date,omitempty"`
+	Validated        bool       `json:"validated,omitempty" xml:"validated,omitempty"`
+}
+
+// RecordSummaryCounts splits the number of items into those validated by a
+// trusted source and those asserted by the record holder.
+type RecordSummaryCounts struct {
+	ValidatedCount    int `json:"validated-count,omitempty" xml:"validated-count,omitempty"`
+	SelfAssertedCount int `json:"self-asserted-count,omitempty" xml:"self-asserted-count,omitempty"`
+}
+
+// Total returns the number of items regardless of source.
+func (c *RecordSummaryCounts) Total() int {
+	if c == nil {
+		return 0
+	}
+	return c.ValidatedCount + c.SelfAssertedCount
+}
+
+type RecordSummaryPeerReviewCounts struct {
+	PeerReviewPublicationGrants int `json:"peer-review-publication-grants,omitempty" xml:"peer-review-publication-grants,omitempty"`
+	SelfAssertedCount           int `json:"self-asserted-count,omitempty" xml:"self-asserted-count,omitempty"`
+	Total                       int `json:"total,omitempty" xml:"total,omitempty"`
+}
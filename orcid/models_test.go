@@ -0,0 +1,220 @@
+package orcid
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestEducationsSummaries(t *testing.T) {
+	var educations Educations
+	err := json.Unmarshal([]byte(`{
+		"affiliation-group": [
+			{
+				"last-modified-date": {"value": 1609459200000},
+				"summaries": [
+					{"education-summary": {"put-code": 1, "role-title": "PhD"}},
+					{"education-summary": {"put-code": 2, "role-title": "MSc"}}
+				]
+			},
+			{
+				"summaries": [
+					{"unknown-summary": {"put-code": 3}}
+				]
+			}
+		],
+		"education-summary": [
+			{"put-code": 4, "role-title": "BSc"}
+		]
+	}`), &educations)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	group := educations.AffiliationGroup[0]
+	if group.LastModifiedDate == nil || group.LastModifiedDate.Value.IsZero() {
+		t.Error("Expected last-modified-date on the affiliation group")
+	}
+	if _, ok := group.Summaries[0].(*EducationSummary); !ok {
+		t.Errorf("Expected *EducationSummary, got %T", group.Summaries[0])
+	}
+	if _, ok := educations.AffiliationGroup[1].Summaries[0].(map[string]interface{}); !ok {
+		t.Errorf("Expected unknown summary kept as a map, got %T", educations.AffiliationGroup[1].Summaries[0])
+	}
+
+	summaries := educations.Summaries()
+	if len(summaries) != 3 {
+		t.Fatalf("Expected 3 education summaries, got %d", len(summaries))
+	}
+	for i, expected := range []int64{4, 1, 2} {
+		if summaries[i].PutCode != expected {
+			t.Errorf("Expected put-code %d at index %d, got %d", expected, i, summaries[i].PutCode)
+		}
+	}
+
+	var nilEducations *Educations
+	if nilEducations.Summaries() != nil {
+		t.Error("Expected nil summaries for nil educations")
+	}
+}
+
+func TestEmploymentsSummaries(t *testing.T) {
+	var employments Employments
+	err := json.Unmarshal([]byte(`{
+		"affiliation-group": [
+			{"summaries": [{"employment-summary": {"put-code": 7, "role-title": "Professor"}}]}
+		]
+	}`), &employments)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	summaries := employments.Summaries()
+	if len(summaries) != 1 || summaries[0].RoleTitle != "Professor" {
+		t.Errorf("Expected the grouped employment summary, got %+v", summaries)
+	}
+}
+
+func TestAffiliationSectionsSummaries(t *testing.T) {
+	var activities ActivitiesSummary
+	err := json.Unmarshal([]byte(`{
+		"distinctions": {"affiliation-group": [{"summaries": [{"distinction-summary": {"put-code": 1}}]}]},
+		"invited-positions": {"affiliation-group": [{"summaries": [{"invited-position-summary": {"put-code": 2}}]}]},
+		"memberships": {"affiliation-group": [{"summaries": [{"membership-summary": {"put-code": 3}}]}]},
+		"qualifications": {"affiliation-group": [{"summaries": [{"qualification-summary": {"put-code": 4}}]}]},
+		"services": {"affiliation-group": [{"summaries": [{"service-summary": {"put-code": 5}}]}]}
+	}`), &activities)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if s := activities.Distinctions.Summaries(); len(s) != 1 || s[0].PutCode != 1 {
+		t.Errorf("Expected distinction 1, got %+v", s)
+	}
+	if s := activities.InvitedPositions.Summaries(); len(s) != 1 || s[0].PutCode != 2 {
+		t.Errorf("Expected invited position 2, got %+v", s)
+	}
+	if s := activities.Memberships.Summaries(); len(s) != 1 || s[0].PutCode != 3 {
+		t.Errorf("Expected membership 3, got %+v", s)
+	}
+	if s := activities.Qualifications.Summaries(); len(s) != 1 || s[0].PutCode != 4 {
+		t.Errorf("Expected qualification 4, got %+v", s)
+	}
+	if s := activities.Services.Summaries(); len(s) != 1 || s[0].PutCode != 5 {
+		t.Errorf("Expected service 5, got %+v", s)
+	}
+}
+
+func TestAffiliationGroupRoundTrip(t *testing.T) {
+	input := `{"summaries":[{"service-summary":{"put-code":5,"role-title":"Reviewer"}},{"unknown-summary":{"put-code":6}}]}`
+	var group AffiliationGroup
+	if err := json.Unmarshal([]byte(input), &group); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	output, err := json.Marshal(&group)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if string(output) != input {
+		t.Errorf("Expected JSON %s, got %s", input, output)
+	}
+
+	data, err := xml.Marshal(&Services{AffiliationGroup: []*AffiliationGroup{&group}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	var services Services
+	if err := xml.Unmarshal(data, &services); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s := services.Summaries(); len(s) != 1 || s[0].PutCode != 5 || s[0].RoleTitle != "Reviewer" {
+		t.Errorf("Expected service 5 after an XML round trip of %s, got %+v", data, s)
+	}
+}
+
+func TestAffiliationGroupXML(t *testing.T) {
+	data := []byte(`<activities:educations xmlns:activities="http://www.orcid.org/ns/activities" xmlns:common="http://www.orcid.org/ns/common" xmlns:education="http://www.orcid.org/ns/education">
+	<activities:affiliation-group>
+		<common:external-ids/>
+		<education:education-summary put-code="1">
+			<common:role-title>PhD</common:role-title>
+		</education:education-summary>
+		<education:education-summary put-code="2">
+			<common:role-title>MSc</common:role-title>
+		</education:education-summary>
+	</activities:affiliation-group>
+</activities:educations>`)
+
+	var educations Educations
+	if err := xml.Unmarshal(data, &educations); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(educations.AffiliationGroup) != 1 || educations.AffiliationGroup[0].ExternalIDs == nil {
+		t.Fatalf("Expected 1 affiliation group with external IDs, got %+v", educations.AffiliationGroup)
+	}
+	summaries := educations.Summaries()
+	if len(summaries) != 2 || summaries[0].PutCode != 1 || summaries[1].RoleTitle != "MSc" {
+		t.Errorf("Expected the two grouped education summaries, got %+v", summaries)
+	}
+}
+
+func TestWorksSummaries(t *testing.T) {
+	var works Works
+	err := json.Unmarshal([]byte(`{
+		"group": [
+			{"work-summary": [
+				{"put-code": 1, "display-index": "0"},
+				{"put-code": 2, "display-index": "1"}
+			]},
+			{"work-summary": [
+				{"put-code": 3, "display-index": "0"},
+				{"put-code": 4, "display-index": "0"}
+			]}
+		]
+	}`), &works)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	all := works.Summaries()
+	if len(all) != 4 {
+		t.Fatalf("Expected 4 summaries, got %d", len(all))
+	}
+	for i, summary := range all {
+		if summary.PutCode != int64(i+1) {
+			t.Errorf("Expected put-code %d at %d, got %d", i+1, i, summary.PutCode)
+		}
+	}
+
+	preferred := works.PreferredSummaries()
+	if len(preferred) != 2 || preferred[0].PutCode != 2 || preferred[1].PutCode != 3 {
+		t.Errorf("Expected put-codes 2 and 3, got %+v", preferred)
+	}
+
+	var nilWorks *Works
+	if nilWorks.Summaries() != nil || nilWorks.PreferredSummaries() != nil {
+		t.Error("Expected nil results for nil works")
+	}
+}
+
+func TestTypedVisibilityAndWorkType(t *testing.T) {
+	var work Work
+	if err := json.Unmarshal([]byte(`{"type": "journal-article", "visibility": "limited"}`), &work); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if work.Type != WorkTypeJournalArticle {
+		t.Errorf("Expected type %s, got %s", WorkTypeJournalArticle, work.Type)
+	}
+	if work.Visibility != VisibilityLimited {
+		t.Errorf("Expected visibility %s, got %s", VisibilityLimited, work.Visibility)
+	}
+
+	data, err := json.Marshal(&Work{Type: WorkTypeDataSet, Visibility: VisibilityPublic})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(string(data), `"type":"data-set"`) || !strings.Contains(string(data), `"visibility":"public"`) {
+		t.Errorf("Expected type and visibility as plain strings, got %s", data)
+	}
+}
@@ -0,0 +1,280 @@
+package orcid
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// Token is an OAuth access token issued by ORCID. AccessToken can be passed
+// to WithBearerToken.
+type Token struct {
+	AccessToken  string
+	TokenType    string
+	RefreshToken string
+	Scope        string
+	ExpiresAt    time.Time
+
+	// ORCID and Name identify the user who authorized the token. They are
+	// empty for client credentials tokens.
+	ORCID string
+	Name  string
+}
+
+// tokenResponse is the body of a successful token request.
+type tokenResponse struct {
+	AccessToken  string `json:"access_token"`
+	TokenType    string `json:"token_type"`
+	RefreshToken string `json:"refresh_token"`
+	ExpiresIn    int64  `json:"expires_in"`
+	Scope        string `json:"scope"`
+	ORCID        string `json:"orcid"`
+	Name         string `json:"name"`
+}
+
+// oauthHTTPClient is used by the OAuth functions that are not tied to a
+// Client.
+var oauthHTTPClient = &http.Client{Timeout: DefaultTimeout}
+
+func siteURL(sandbox bool) string {
+	if sandbox {
+		return SandboxSiteURL
+	}
+	return SiteURL
+}
+
+// GetReadPublicToken obtains a token with the /read-public scope using the
+// client credentials of an ORCID API client. Such tokens are long-lived and
+// can be shared by all requests to the public API.
+func GetReadPublicToken(ctx context.Context, clientID, clientSecret string, sandbox bool) (*Token, error) {
+	form := url.Values{}
+	form.Set("client_id", clientID)
+	form.Set("client_secret", clientSecret)
+	form.Set("grant_type", "client_credentials")
+	form.Set("scope", ScopeReadPublic)
+
+	return requestToken(ctx, oauthHTTPClient, siteURL(sandbox)+"/oauth/token", form)
+}
+
+// AuthorizationURL returns the orcid.org page where a user signs in and grants
+// the scopes to the client, after which ORCID redirects to redirectURI with an
+// authorization code for ExchangeCode. state is echoed back to redirectURI
+// and is omitted when empty. Scopes such as "/authenticate" are joined with
+// spaces; an error is returned when no scope is given, since ORCID rejects
+// such requests.
+func AuthorizationURL(clientID, redirectURI string, scopes []string, state string, sandbox bool) (string, error) {
+	var nonEmpty []string
+	for _, scope := range scopes {
+		if scope = strings.TrimSpace(scope); scope != "" {
+			nonEmpty = append(nonEmpty, scope)
+		}
+	}
+	if len(nonEmpty) == 0 {
+		return "", fmt.Errorf("at least one scope is required")
+	}
+
+	params := url.Values{}
+	params.Set("client_id", clientID)
+	params.Set("response_type", "code")
+	params.Set("scope", strings.Join(nonEmpty, " "))
+	params.Set("redirect_uri", redirectURI)
+	if state != "" {
+		params.Set("state", state)
+	}
+
+	return siteURL(sandbox) + "/oauth/authorize?" + params.Encode(), nil
+}
+
+// ExchangeCode trades the authorization code ORCID sent to redirectURI for an
+// access token. redirectURI must be the one used to build the authorization
+// URL. The token's ORCID field holds the iD of the user who signed in.
+func (c *Client) ExchangeCode(ctx context.Context, clientID, clientSecret, code, redirectURI string) (*Token, error) {
+	form := url.Values{}
+	form.Set("client_id", clientID)
+	form.Set("client_secret", clientSecret)
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", redirectURI)
+
+	return requestToken(ctx, c.httpClient, c.oauthURL()+"/token", form)
+}
+
+// RevokeToken invalidates an access or refresh token issued to the given API
+// client, for example when a user signs out.
+func (c *Client) RevokeToken(ctx context.Context, clientID, clientSecret, token string) error {
+	if token == "" {
+		return fmt.Errorf("token is required")
+	}
+
+	form := url.Values{}
+	form.Set("client_id", clientID)
+	form.Set("client_secret", clientSecret)
+	form.Set("token", token)
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.oauthURL()+"/revoke", strings.NewReader(form.Encode()))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		data, _ := io.ReadAll(resp.Body)
+		return fmt.Errorf("token revocation failed: %w", newOAuthError(resp, data))
+	}
+	return nil
+}
+
+// tokenRefreshMargin is how close to expiry a refreshable token is renewed.
+const tokenRefreshMargin = 60 * time.Second
+
+// tokenRefreshBackoff is how long a failed refresh is not retried while the
+// current token is still valid, so that requests in the last minute before
+// expiry do not each wait for a refresh attempt.
+const tokenRefreshBackoff = 10 * time.Second
+
+// WithRefreshableToken authenticates with token and renews it with its
+// refresh token, using the given API client credentials, whenever a request
+// is made within a minute of its expiry. Use OnTokenRefresh to persist the
+// renewed token.
+func WithRefreshableToken(token *Token, clientID, clientSecret string) ClientOption {
+	return func(c *Client) {
+		c.refreshableToken = token
+		c.oauthClientID = clientID
+		c.oauthClientSecret = clientSecret
+		if token != nil {
+			c.bearerToken = token.AccessToken
+		}
+	}
+}
+
+// OnTokenRefresh registers a function called with the new token each time
+// the client renews a token set with WithRefreshableToken. It is called from
+// the goroutine making the request that triggered the refresh.
+func OnTokenRefresh(fn func(*Token)) ClientOption {
+	return func(c *Client) {
+		c.onTokenRefresh = fn
+	}
+}
+
+// accessToken returns the bearer token for a request, first refreshing the
+// refreshable token if it is about to expire. Concurrent callers wait for a
+// single refresh. A failed refresh falls back to the current token until it
+// has actually expired, and is tried again after tokenRefreshBackoff, or on
+// the next request once the token has expired.
+func (c *Client) accessToken(ctx context.Context) (string, error) {
+	c.tokenMu.Lock()
+	token := c.refreshableToken
+	if token == nil {
+		c.tokenMu.Unlock()
+		return c.bearerToken, nil
+	}
+	now := time.Now()
+	if token.ExpiresAt.IsZero() || token.ExpiresAt.Sub(now) > tokenRefreshMargin || token.RefreshToken == "" ||
+		(now.Before(c.refreshRetryAt) && now.Before(token.ExpiresAt)) {
+		c.tokenMu.Unlock()
+		return token.AccessToken, nil
+	}
+
+	form := url.Values{}
+	form.Set("client_id", c.oauthClientID)
+	form.Set("client_secret", c.oauthClientSecret)
+	form.Set("grant_type", "refresh_token")
+	form.Set("refresh_token", token.RefreshToken)
+
+	refreshed, err := requestToken(ctx, c.httpClient, c.oauthURL()+"/token", form)
+	if err != nil {
+		c.refreshRetryAt = time.Now().Add(tokenRefreshBackoff)
+		c.tokenMu.Unlock()
+		if time.Now().Before(token.ExpiresAt) {
+			return token.AccessToken, nil
+		}
+		return "", fmt.Errorf("refreshing token: %w", err)
+	}
+	// ORCID may leave out fields that carry over from the original token
+	if refreshed.RefreshToken == "" {
+		refreshed.RefreshToken = token.RefreshToken
+	}
+	if refreshed.ORCID == "" {
+		refreshed.ORCID = token.ORCID
+		refreshed.Name = token.Name
+	}
+	c.refreshableToken = refreshed
+	c.tokenMu.Unlock()
+
+	if c.onTokenRefresh != nil {
+		persisted := *refreshed
+		c.onTokenRefresh(&persisted)
+	}
+	return refreshed.AccessToken, nil
+}
+
+// oauthURL returns the OAuth base URL matching the client's API host: the
+// production or sandbox orcid.org site, or the root of any other host, such as
+// a proxy or test server.
+func (c *Client) oauthURL() string {
+	u, err := url.Parse(c.apiURL)
+	if err != nil || u.Host == "" {
+		return SiteURL + "/oauth"
+	}
+	if host := u.Hostname(); isORCIDHost(host) {
+		return siteURL(strings.Contains(host, "sandbox")) + "/oauth"
+	}
+	return u.Scheme + "://" + u.Host + "/oauth"
+}
+
+// requestToken posts form to the token endpoint and decodes the token.
+func requestToken(ctx context.Context, httpClient *http.Client, tokenURL string, form url.Values) (*Token, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, tokenURL, strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token request failed: %w", newOAuthError(resp, data))
+	}
+
+	var body tokenResponse
+	if err := json.Unmarshal(data, &body); err != nil {
+		return nil, err
+	}
+	if body.AccessToken == "" {
+		return nil, fmt.Errorf("token response has no access token")
+	}
+
+	token := &Token{
+		AccessToken:  body.AccessToken,
+		TokenType:    body.TokenType,
+		RefreshToken: body.RefreshToken,
+		Scope:        body.Scope,
+		ORCID:        body.ORCID,
+		Name:         body.Name,
+	}
+	if body.ExpiresIn > 0 {
+		token.ExpiresAt = time.Now().Add(time.Duration(body.ExpiresIn) * time.Second)
+	}
+	return token, nil
+}
@@ -0,0 +1,369 @@
+package orcid
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestRequestTokenClientCredentials(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected method %s, got %s", http.MethodPost, r.Method)
+		}
+		if r.URL.Path != "/oauth/token" {
+			t.Errorf("Expected path %s, got %s", "/oauth/token", r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Unexpected error parsing form: %v", err)
+		}
+		expected := map[string]string{
+			"client_id":     "APP-123",
+			"client_secret": "secret",
+			"grant_type":    "client_credentials",
+			"scope":         "/read-public",
+		}
+		for key, value := range expected {
+			if r.PostForm.Get(key) != value {
+				t.Errorf("Expected %s %s, got %s", key, value, r.PostForm.Get(key))
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"access_token": "token-abc",
+			"token_type": "bearer",
+			"refresh_token": "refresh-abc",
+			"expires_in": 631138518,
+			"scope": "/read-public",
+			"orcid": null
+		}`))
+	}))
+	defer server.Close()
+
+	form := map[string][]string{
+		"client_id":     {"APP-123"},
+		"client_secret": {"secret"},
+		"grant_type":    {"client_credentials"},
+		"scope":         {ScopeReadPublic},
+	}
+	token, err := requestToken(context.Background(), server.Client(), server.URL+"/oauth/token", form)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if token.AccessToken != "token-abc" || token.TokenType != "bearer" || token.Scope != "/read-public" {
+		t.Errorf("Unexpected token: %+v", token)
+	}
+	if token.ExpiresAt.Before(time.Now().Add(20 * 365 * 24 * time.Hour)) {
+		t.Errorf("Expected expiry about 20 years ahead, got %v", token.ExpiresAt)
+	}
+}
+
+func TestRequestTokenError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte(`{"error": "invalid_client", "error_description": "Client not found: APP-123"}`))
+	}))
+	defer server.Close()
+
+	_, err := requestToken(context.Background(), server.Client(), server.URL+"/oauth/token", nil)
+	if err == nil {
+		t.Fatal("Expected error for rejected credentials")
+	}
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.OAuthError != "invalid_client" || apiErr.DeveloperMessage != "Client not found: APP-123" {
+		t.Errorf("Expected an *APIError with the OAuth error, got %#v", err)
+	}
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected rejected credentials to match ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestRequestTokenInvalidGrant(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"error": "invalid_grant", "error_description": "Invalid refresh token"}`))
+	}))
+	defer server.Close()
+
+	_, err := requestToken(context.Background(), server.Client(), server.URL+"/oauth/token", nil)
+	if !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected a revoked refresh token to match ErrUnauthorized, got %v", err)
+	}
+}
+
+func TestSiteURL(t *testing.T) {
+	if siteURL(false) != "https://orcid.org" {
+		t.Errorf("Expected %s, got %s", "https://orcid.org", siteURL(false))
+	}
+	if siteURL(true) != "https://sandbox.orcid.org" {
+		t.Errorf("Expected %s, got %s", "https://sandbox.orcid.org", siteURL(true))
+	}
+}
+
+func TestAuthorizationURL(t *testing.T) {
+	authURL, err := AuthorizationURL("APP-123", "https://example.org/callback?from=login", []string{"/authenticate", "/activities/update"}, "a b&c", true)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	parsed, err := url.Parse(authURL)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if parsed.Scheme+"://"+parsed.Host+parsed.Path != "https://sandbox.orcid.org/oauth/authorize" {
+		t.Errorf("Expected sandbox authorize endpoint, got %s", authURL)
+	}
+
+	query := parsed.Query()
+	expected := map[string]string{
+		"client_id":     "APP-123",
+		"response_type": "code",
+		"scope":         "/authenticate /activities/update",
+		"redirect_uri":  "https://example.org/callback?from=login",
+		"state":         "a b&c",
+	}
+	for key, value := range expected {
+		if query.Get(key) != value {
+			t.Errorf("Expected %s %q, got %q", key, value, query.Get(key))
+		}
+	}
+
+	if authURL, err := AuthorizationURL("APP-123", "https://example.org/callback", []string{"/authenticate"}, "", false); err != nil || !strings.HasPrefix(authURL, "https://orcid.org/oauth/authorize?") || strings.Contains(authURL, "state=") {
+		t.Errorf("Expected production URL without state, got %s (%v)", authURL, err)
+	}
+
+	if authURL, err := AuthorizationURL("APP-123", "https://example.org/callback", []string{" "}, "", false); err == nil || authURL != "" {
+		t.Errorf("Expected an error and no URL without scopes, got %q (%v)", authURL, err)
+	}
+}
+
+func TestExchangeCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oauth/token" {
+			t.Errorf("Expected path %s, got %s", "/oauth/token", r.URL.Path)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatalf("Unexpected error parsing form: %v", err)
+		}
+		if r.PostForm.Get("grant_type") != "authorization_code" {
+			t.Errorf("Expected grant_type %s, got %s", "authorization_code", r.PostForm.Get("grant_type"))
+		}
+		if r.PostForm.Get("code") != "Q70Y3A" {
+			t.Errorf("Expected code %s, got %s", "Q70Y3A", r.PostForm.Get("code"))
+		}
+		if r.PostForm.Get("redirect_uri") != "https://example.org/callback" {
+			t.Errorf("Expected redirect_uri %s, got %s", "https://example.org/callback", r.PostForm.Get("redirect_uri"))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"access_token": "token-abc",
+			"token_type": "bearer",
+			"refresh_token": "refresh-abc",
+			"expires_in": 631138518,
+			"scope": "/authenticate",
+			"name": "Josiah Carberry",
+			"orcid": "0000-0002-1825-0097"
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIURL(server.URL + "/v3.0"))
+	token, err := client.ExchangeCode(context.Background(), "APP-123", "secret", "Q70Y3A", "https://example.org/callback")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if token.ORCID != "0000-0002-1825-0097" {
+		t.Errorf("Expected ORCID %s, got %s", "0000-0002-1825-0097", token.ORCID)
+	}
+	if token.Name != "Josiah Carberry" || token.RefreshToken != "refresh-abc" {
+		t.Errorf("Unexpected token: %+v", token)
+	}
+}
+
+func TestClientOAuthURL(t *testing.T) {
+	tests := []struct {
+		apiURL   string
+		expected string
+	}{
+		{PublicHost, "https://orcid.org/oauth"},
+		{MemberHost, "https://orcid.org/oauth"},
+		{PublicSandboxHost, "https://sandbox.orcid.org/oauth"},
+		{MemberSandboxHost, "https://sandbox.orcid.org/oauth"},
+		{"http://localhost:8080/v3.0", "http://localhost:8080/oauth"},
+	}
+	for _, tt := range tests {
+		client := NewClient(WithAPIURL(tt.apiURL))
+		if got := client.oauthURL(); got != tt.expected {
+			t.Errorf("Expected %s for %s, got %s", tt.expected, tt.apiURL, got)
+		}
+	}
+}
+
+func TestRefreshableToken(t *testing.T) {
+	var refreshes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			refreshes.Add(1)
+			r.ParseForm()
+			if r.PostForm.Get("grant_type") != "refresh_token" || r.PostForm.Get("refresh_token") != "refresh-old" {
+				t.Errorf("Unexpected refresh form: %v", r.PostForm)
+			}
+			// Give concurrent requests time to pile up behind the refresh
+			time.Sleep(50 * time.Millisecond)
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"access_token": "token-new", "token_type": "bearer", "expires_in": 3600, "scope": "/read-limited"}`))
+			return
+		}
+
+		if auth := r.Header.Get("Authorization"); auth != "Bearer token-new" {
+			t.Errorf("Expected refreshed token, got %s", auth)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"orcid-identifier": {"path": "0000-0002-1825-0097"}}`))
+	}))
+	defer server.Close()
+
+	var persisted []*Token
+	var mu sync.Mutex
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithRateLimit(0),
+		WithRefreshableToken(&Token{
+			AccessToken:  "token-old",
+			RefreshToken: "refresh-old",
+			ExpiresAt:    time.Now().Add(30 * time.Second),
+			ORCID:        "0000-0002-1825-0097",
+		}, "APP-123", "secret"),
+		OnTokenRefresh(func(token *Token) {
+			mu.Lock()
+			defer mu.Unlock()
+			persisted = append(persisted, token)
+		}),
+	)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetRecord(context.Background(), "0000-0002-1825-0097"); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+	wg.Wait()
+
+	if n := refreshes.Load(); n != 1 {
+		t.Errorf("Expected 1 refresh, got %d", n)
+	}
+	if len(persisted) != 1 {
+		t.Fatalf("Expected 1 refresh callback, got %d", len(persisted))
+	}
+	if persisted[0].AccessToken != "token-new" || persisted[0].RefreshToken != "refresh-old" || persisted[0].ORCID != "0000-0002-1825-0097" {
+		t.Errorf("Unexpected refreshed token: %+v", persisted[0])
+	}
+}
+
+func TestRefreshableTokenRefreshFails(t *testing.T) {
+	var refreshes atomic.Int32
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/oauth/token" {
+			refreshes.Add(1)
+			w.WriteHeader(http.StatusServiceUnavailable)
+			w.Write([]byte(`{"error": "temporarily_unavailable"}`))
+			return
+		}
+
+		if auth := r.Header.Get("Authorization"); auth != "Bearer token-old" {
+			t.Errorf("Expected the unexpired token, got %s", auth)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"orcid-identifier": {"path": "0000-0002-1825-0097"}}`))
+	}))
+	defer server.Close()
+
+	token := &Token{
+		AccessToken:  "token-old",
+		RefreshToken: "refresh-old",
+		ExpiresAt:    time.Now().Add(30 * time.Second),
+	}
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithRateLimit(0),
+		WithMaxRetries(0),
+		WithRefreshableToken(token, "APP-123", "secret"),
+	)
+	ctx := context.Background()
+
+	if _, err := client.GetRecord(ctx, "0000-0002-1825-0097"); err != nil {
+		t.Fatalf("Expected the unexpired token to be used, got %v", err)
+	}
+	if n := refreshes.Load(); n != 1 {
+		t.Errorf("Expected 1 refresh attempt, got %d", n)
+	}
+
+	// Requests soon after the failure use the token without retrying
+	if _, err := client.GetRecord(ctx, "0000-0002-1825-0097"); err != nil {
+		t.Fatalf("Expected the unexpired token to be used, got %v", err)
+	}
+	if n := refreshes.Load(); n != 1 {
+		t.Errorf("Expected no refresh attempt during the backoff, got %d attempts", n)
+	}
+
+	token.ExpiresAt = time.Now().Add(-time.Second)
+	_, err := client.GetRecord(ctx, "0000-0002-1825-0097")
+	if err == nil || !strings.Contains(err.Error(), "refreshing token") {
+		t.Errorf("Expected the refresh error once the token expired, got %v", err)
+	}
+}
+
+func TestRevokeToken(t *testing.T) {
+	revoked := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/oauth/revoke" {
+			t.Errorf("Expected POST to %s, got %s %s", "/oauth/revoke", r.Method, r.URL.Path)
+		}
+		r.ParseForm()
+		if r.PostForm.Get("client_id") != "APP-123" || r.PostForm.Get("client_secret") != "secret" {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"error": "invalid_client"}`))
+			return
+		}
+		revoked[r.PostForm.Get("token")] = true
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIURL(server.URL + "/v3.0"))
+	ctx := context.Background()
+
+	if err := client.RevokeToken(ctx, "APP-123", "secret", "token-abc"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !revoked["token-abc"] {
+		t.Error("Expected token-abc to be revoked")
+	}
+
+	if err := client.RevokeToken(ctx, "APP-123", "wrong", "token-abc"); !errors.Is(err, ErrUnauthorized) {
+		t.Errorf("Expected ErrUnauthorized for invalid client credentials, got %v", err)
+	}
+	if err := client.RevokeToken(ctx, "APP-123", "secret", ""); err == nil {
+		t.Error("Expected error for empty token")
+	}
+}
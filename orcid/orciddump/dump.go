@@ -0,0 +1,74 @@
+// Package orciddump reads ORCID's public data file, the yearly dump of every
+// public record, so the corpus can be processed offline rather than through
+// millions of API calls.
+//
+//	f, err := os.Open("ORCID_2024_10_summaries.tar.gz")
+//	if err != nil {
+//		log.Fatal(err)
+//	}
+//	defer f.Close()
+//	err = orciddump.ParseSummariesTarGz(f, func(record *orcid.Record) error {
+//		fmt.Println(record.OrcidIdentifier.Path, record.FullName())
+//		return nil
+//	})
+package orciddump
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"encoding/json"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"path"
+	"strings"
+
+	"github.com/Epistemic-Technology/orcid/orcid"
+)
+
+// ParseSummariesTarGz streams the gzipped tar archive of record summaries
+// read from r, decoding each .xml or .json entry into an orcid.Record and
+// passing it to fn. Other entries, such as directories, are skipped.
+//
+// The archive is read sequentially, so only one record is held in memory at
+// a time. fn must not retain the record beyond the call if memory matters.
+// Parsing stops at the first error, either from reading the archive or
+// returned by fn; the latter is returned unwrapped.
+func ParseSummariesTarGz(r io.Reader, fn func(*orcid.Record) error) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("reading gzip stream: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reading tar archive: %w", err)
+		}
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		var record orcid.Record
+		switch strings.ToLower(path.Ext(header.Name)) {
+		case ".xml":
+			err = xml.NewDecoder(tr).Decode(&record)
+		case ".json":
+			err = json.NewDecoder(tr).Decode(&record)
+		default:
+			continue
+		}
+		if err != nil {
+			return fmt.Errorf("decoding %s: %w", header.Name, err)
+		}
+		if err := fn(&record); err != nil {
+			return err
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package orciddump
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"errors"
+	"testing"
+
+	"github.com/Epistemic-Technology/orcid/orcid"
+)
+
+const xmlSummary = `<?xml version="1.0" encoding="UTF-8" standalone="yes"?>
+<record:record path="/0000-0002-1825-0097" xmlns:common="http://www.orcid.org/ns/common" xmlns:person="http://www.orcid.org/ns/person" xmlns:personal-details="http://www.orcid.org/ns/personal-details" xmlns:record="http://www.orcid.org/ns/record">
+    <common:orcid-identifier>
+        <common:uri>https://orcid.org/0000-0002-1825-0097</common:uri>
+        <common:path>0000-0002-1825-0097</common:path>
+        <common:host>orcid.org</common:host>
+    </common:orcid-identifier>
+    <person:person path="/0000-0002-1825-0097/person">
+        <person:name visibility="public" path="0000-0002-1825-0097">
+            <personal-details:given-names>Josiah</personal-details:given-names>
+            <personal-details:family-name>Carberry</personal-details:family-name>
+        </person:name>
+    </person:person>
+</record:record>
+`
+
+const jsonSummary = `{
+  "orcid-identifier": {"path": "0000-0002-1694-233X"},
+  "person": {"name": {"given-names": {"value": "Ada"}, "family-name": {"value": "Lovelace"}}}
+}`
+
+func writeArchive(t *testing.T, files map[string]string, names ...string) *bytes.Buffer {
+	t.Helper()
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	if err := tw.WriteHeader(&tar.Header{Name: "ORCID_summaries/", Typeflag: tar.TypeDir, Mode: 0o755}); err != nil {
+		t.Fatal(err)
+	}
+	for _, name := range names {
+		body := files[name]
+		if err := tw.WriteHeader(&tar.Header{Name: name, Typeflag: tar.TypeReg, Mode: 0o644, Size: int64(len(body))}); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := tw.Write([]byte(body)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gz.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return &buf
+}
+
+func TestParseSummariesTarGz(t *testing.T) {
+	files := map[string]string{
+		"ORCID_summaries/097/0000-0002-1825-0097.xml":  xmlSummary,
+		"ORCID_summaries/33X/0000-0002-1694-233X.json": jsonSummary,
+		"ORCID_summaries/README.txt":                   "not a record",
+	}
+	archive := writeArchive(t, files,
+		"ORCID_summaries/097/0000-0002-1825-0097.xml",
+		"ORCID_summaries/README.txt",
+		"ORCID_summaries/33X/0000-0002-1694-233X.json")
+
+	var records []*orcid.Record
+	err := ParseSummariesTarGz(archive, func(record *orcid.Record) error {
+		records = append(records, record)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(records) != 2 {
+		t.Fatalf("Expected 2 records, got %d", len(records))
+	}
+
+	if records[0].OrcidIdentifier == nil || records[0].OrcidIdentifier.Path != "0000-0002-1825-0097" {
+		t.Errorf("Expected XML record 0000-0002-1825-0097, got %+v", records[0].OrcidIdentifier)
+	}
+	if got := records[0].FullName(); got != "Josiah Carberry" {
+		t.Errorf("Expected name Josiah Carberry, got %q", got)
+	}
+	if records[0].Person.Name.Visibility != orcid.VisibilityPublic {
+		t.Errorf("Expected public name, got %q", records[0].Person.Name.Visibility)
+	}
+
+	if records[1].OrcidIdentifier == nil || records[1].OrcidIdentifier.Path != "0000-0002-1694-233X" {
+		t.Errorf("Expected JSON record 0000-0002-1694-233X, got %+v", records[1].OrcidIdentifier)
+	}
+	if got := records[1].FullName(); got != "Ada Lovelace" {
+		t.Errorf("Expected name Ada Lovelace, got %q", got)
+	}
+}
+
+func TestParseSummariesTarGzCallbackError(t *testing.T) {
+	files := map[string]string{
+		"a.xml": xmlSummary,
+		"b.xml": xmlSummary,
+	}
+	archive := writeArchive(t, files, "a.xml", "b.xml")
+
+	stop := errors.New("stop")
+	calls := 0
+	err := ParseSummariesTarGz(archive, func(*orcid.Record) error {
+		calls++
+		return stop
+	})
+	if err != stop {
+		t.Errorf("Expected the callback's error, got %v", err)
+	}
+	if calls != 1 {
+		t.Errorf("Expected parsing to stop after 1 record, got %d calls", calls)
+	}
+}
+
+func TestParseSummariesTarGzInvalidInput(t *testing.T) {
+	err := ParseSummariesTarGz(bytes.NewReader([]byte("not gzip")), func(*orcid.Record) error {
+		return nil
+	})
+	if err == nil {
+		t.Error("Expected an error for input that is not gzip")
+	}
+
+	archive := writeArchive(t, map[string]string{"bad.json": "{"}, "bad.json")
+	err = ParseSummariesTarGz(archive, func(*orcid.Record) error {
+		return nil
+	})
+	if err == nil {
+		t.Error("Expected an error for a malformed record")
+	}
+}
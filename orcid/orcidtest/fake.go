@@ -0,0 +1,327 @@
+// Package orcidtest provides an in-memory ORCID API for testing code that
+// uses the orcid client, so tests can run against realistic responses
+// without writing httptest handlers by hand.
+//
+//	srv := orcidtest.NewFakeServer()
+//	defer srv.Close()
+//	srv.AddRecord("0000-0002-1825-0097", record)
+//	client := srv.Client()
+//	defer client.Close()
+package orcidtest
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/Epistemic-Technology/orcid/orcid"
+)
+
+// APIVersion is the version segment the fake serves its routes under.
+const APIVersion = "v3.0"
+
+// FakeServer is an HTTP server implementing the read routes of the ORCID
+// API from seeded fixtures: records and their person and activity sections,
+// single and bulk works, and search. Responses are always JSON. Requests for
+// unknown iDs or put-codes get HTTP 404 with an ORCID error body.
+//
+// A FakeServer is safe for concurrent use, and fixtures may be added while
+// it is serving. Fixtures are served as given, so they should not be
+// modified after being added.
+type FakeServer struct {
+	server *httptest.Server
+
+	mu      sync.Mutex
+	records map[string]*orcid.Record
+	works   map[string]map[int64]*orcid.Work
+}
+
+// NewFakeServer starts a FakeServer with no fixtures. Call Close when done.
+func NewFakeServer() *FakeServer {
+	s := &FakeServer{
+		records: make(map[string]*orcid.Record),
+		works:   make(map[string]map[int64]*orcid.Work),
+	}
+	s.server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// URL returns the API URL of the server, including the version segment,
+// for use with orcid.WithAPIURL.
+func (s *FakeServer) URL() string {
+	return s.server.URL + "/" + APIVersion
+}
+
+// Close shuts the server down.
+func (s *FakeServer) Close() {
+	s.server.Close()
+}
+
+// Client returns a client wired to the server, with a bearer token set and
+// rate limiting disabled. opts are applied after those defaults, except that
+// the API URL and JSON content type cannot be overridden.
+func (s *FakeServer) Client(opts ...orcid.ClientOption) *orcid.Client {
+	all := []orcid.ClientOption{
+		orcid.WithBearerToken("orcidtest-token"),
+		orcid.WithRateLimit(0),
+	}
+	all = append(all, opts...)
+	all = append(all, orcid.WithAPIURL(s.URL()), orcid.WithContentType(orcid.ContentTypeJSON))
+	return orcid.NewClient(all...)
+}
+
+// AddRecord seeds the record served for orcidID. The person and activity
+// section routes are answered from the corresponding parts of record.
+func (s *FakeServer) AddRecord(orcidID string, record *orcid.Record) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.records[orcidID] = record
+}
+
+// AddWorks seeds the works section of orcidID, replacing the one in its
+// record; like AddWork, it updates a record added with AddRecord in place. A
+// record with no other content is created if needed.
+func (s *FakeServer) AddWorks(orcidID string, works *orcid.Works) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	activities := s.activities(orcidID)
+	activities.Works = works
+}
+
+// AddWork seeds the full work served for its put-code, by the single and
+// bulk work routes. If the works section of orcidID has no summary with that
+// put-code, one is added in a group of its own.
+func (s *FakeServer) AddWork(orcidID string, work *orcid.Work) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.works[orcidID] == nil {
+		s.works[orcidID] = make(map[int64]*orcid.Work)
+	}
+	s.works[orcidID][work.PutCode] = work
+
+	activities := s.activities(orcidID)
+	if activities.Works == nil {
+		activities.Works = &orcid.Works{}
+	}
+	for _, group := range activities.Works.WorkGroup {
+		for _, summary := range group.WorkSummary {
+			if summary.PutCode == work.PutCode {
+				return
+			}
+		}
+	}
+	activities.Works.WorkGroup = append(activities.Works.WorkGroup, &orcid.WorkGroup{
+		ExternalIDs: work.ExternalIDs,
+		WorkSummary: []*orcid.WorkSummary{{
+			PutCode:         work.PutCode,
+			Title:           work.Title,
+			ExternalIDs:     work.ExternalIDs,
+			Type:            work.Type,
+			PublicationDate: work.PublicationDate,
+			JournalTitle:    work.JournalTitle,
+			Visibility:      work.Visibility,
+			Path:            orcid.Path(fmt.Sprintf("/%s/work/%d", orcidID, work.PutCode)),
+		}},
+	})
+}
+
+// activities returns the activities summary of orcidID's record, creating
+// the record and summary as needed. s.mu must be held.
+func (s *FakeServer) activities(orcidID string) *orcid.ActivitiesSummary {
+	record, ok := s.records[orcidID]
+	if !ok {
+		record = &orcid.Record{
+			OrcidIdentifier: &orcid.OrcidIdentifier{Path: orcid.Path(orcidID)},
+		}
+		s.records[orcidID] = record
+	}
+	if record.ActivitiesSummary == nil {
+		record.ActivitiesSummary = &orcid.ActivitiesSummary{}
+	}
+	return record.ActivitiesSummary
+}
+
+func (s *FakeServer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet {
+		writeError(w, http.StatusMethodNotAllowed, "the fake server only supports reads")
+		return
+	}
+	if !strings.HasPrefix(r.Header.Get("Authorization"), "Bearer ") {
+		writeError(w, http.StatusUnauthorized, "missing bearer token")
+		return
+	}
+
+	path, ok := strings.CutPrefix(r.URL.Path, "/"+APIVersion+"/")
+	if !ok {
+		writeError(w, http.StatusNotFound, "unsupported API version")
+		return
+	}
+	parts := strings.Split(strings.Trim(path, "/"), "/")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if parts[0] == "search" {
+		s.serveSearch(w, r)
+		return
+	}
+
+	record, ok := s.records[parts[0]]
+	if !ok {
+		writeError(w, http.StatusNotFound, "ORCID iD "+parts[0]+" not found")
+		return
+	}
+
+	section := "record"
+	if len(parts) > 1 {
+		section = parts[1]
+	}
+	if len(parts) == 3 && (section == "work" || section == "works") {
+		s.serveWorks(w, parts[0], section, parts[2])
+		return
+	}
+	if len(parts) > 2 {
+		writeError(w, http.StatusNotFound, "unsupported path "+r.URL.Path)
+		return
+	}
+
+	v, ok := recordSection(record, section)
+	if !ok {
+		writeError(w, http.StatusNotFound, "unsupported path "+r.URL.Path)
+		return
+	}
+	writeJSON(w, v)
+}
+
+// recordSection returns the part of record served by a section route.
+func recordSection(record *orcid.Record, section string) (interface{}, bool) {
+	if section == "record" {
+		return record, true
+	}
+
+	person := record.Person
+	if person == nil {
+		person = &orcid.Person{}
+	}
+	switch section {
+	case "person":
+		return person, true
+	case "biography":
+		return person.Biography, true
+	case "keywords":
+		return person.Keywords, true
+	case "email":
+		return person.Emails, true
+	}
+
+	activities := record.ActivitiesSummary
+	if activities == nil {
+		activities = &orcid.ActivitiesSummary{}
+	}
+	switch section {
+	case "activities":
+		return activities, true
+	case "works":
+		return activities.Works, true
+	case "educations":
+		return activities.Educations, true
+	case "employments":
+		return activities.Employments, true
+	case "fundings":
+		return activities.Fundings, true
+	case "peer-reviews":
+		return activities.PeerReviews, true
+	case "distinctions":
+		return activities.Distinctions, true
+	case "invited-positions":
+		return activities.InvitedPositions, true
+	case "memberships":
+		return activities.Memberships, true
+	case "qualifications":
+		return activities.Qualifications, true
+	case "services":
+		return activities.Services, true
+	case "research-resources":
+		return activities.ResearchResources, true
+	}
+	return nil, false
+}
+
+// serveWorks answers /work/{put-code} and the bulk /works/{put-code,...}.
+func (s *FakeServer) serveWorks(w http.ResponseWriter, orcidID, section, codes string) {
+	if section == "work" {
+		putCode, err := strconv.ParseInt(codes, 10, 64)
+		work, ok := s.works[orcidID][putCode]
+		if err != nil || !ok {
+			writeError(w, http.StatusNotFound, "work "+codes+" not found")
+			return
+		}
+		writeJSON(w, work)
+		return
+	}
+
+	var bulk orcid.WorkBulk
+	for _, code := range strings.Split(codes, ",") {
+		putCode, err := strconv.ParseInt(code, 10, 64)
+		if work, ok := s.works[orcidID][putCode]; err == nil && ok {
+			bulk.Bulk = append(bulk.Bulk, &orcid.WorkBulkItem{Work: work})
+			continue
+		}
+		bulk.Bulk = append(bulk.Bulk, &orcid.WorkBulkItem{Error: &orcid.OrcidError{
+			ResponseCode:     http.StatusNotFound,
+			DeveloperMessage: "work " + code + " not found",
+			ErrorCode:        9016,
+		}})
+	}
+	writeJSON(w, &bulk)
+}
+
+// serveSearch answers every query with all seeded iDs in order, paged by the
+// start and rows parameters. The query itself is not evaluated.
+func (s *FakeServer) serveSearch(w http.ResponseWriter, r *http.Request) {
+	ids := make([]string, 0, len(s.records))
+	for id := range s.records {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+
+	query := r.URL.Query()
+	start, _ := strconv.Atoi(query.Get("start"))
+	rows := 10
+	if v := query.Get("rows"); v != "" {
+		rows, _ = strconv.Atoi(v)
+	}
+	start = min(max(start, 0), len(ids))
+	end := min(start+max(rows, 0), len(ids))
+
+	result := &orcid.SearchResult{
+		NumFound: len(ids),
+		Start:    start,
+		NumRows:  end - start,
+	}
+	for _, id := range ids[start:end] {
+		result.Results = append(result.Results, &orcid.SearchRecord{
+			OrcidIdentifier: &orcid.OrcidIdentifier{Path: orcid.Path(id)},
+		})
+	}
+	writeJSON(w, result)
+}
+
+func writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", string(orcid.ContentTypeJSON))
+	json.NewEncoder(w).Encode(v)
+}
+
+func writeError(w http.ResponseWriter, status int, message string) {
+	w.Header().Set("Content-Type", string(orcid.ContentTypeJSON))
+	w.WriteHeader(status)
+	json.NewEncoder(w).Encode(&orcid.OrcidError{
+		ResponseCode:     status,
+		DeveloperMessage: message,
+	})
+}
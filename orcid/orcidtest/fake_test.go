@@ -0,0 +1,108 @@
+package orcidtest
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Epistemic-Technology/orcid/orcid"
+)
+
+func testRecord() *orcid.Record {
+	return &orcid.Record{
+		OrcidIdentifier: &orcid.OrcidIdentifier{Path: "0000-0002-1825-0097"},
+		Person: &orcid.Person{
+			Name:      &orcid.Name{FamilyName: &orcid.FamilyName{Value: "Carberry"}},
+			Biography: &orcid.Biography{Content: "Fictional researcher"},
+		},
+	}
+}
+
+func TestFakeServerRecord(t *testing.T) {
+	srv := NewFakeServer()
+	defer srv.Close()
+	srv.AddRecord("0000-0002-1825-0097", testRecord())
+
+	client := srv.Client()
+	defer client.Close()
+	ctx := context.Background()
+
+	record, err := client.GetRecord(ctx, "0000-0002-1825-0097")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got := record.Person.Name.FamilyName.Value; got != "Carberry" {
+		t.Errorf("Expected family name Carberry, got %s", got)
+	}
+
+	biography, err := client.GetBiography(ctx, "0000-0002-1825-0097")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if biography.Content != "Fictional researcher" {
+		t.Errorf("Expected biography %q, got %q", "Fictional researcher", biography.Content)
+	}
+
+	if _, err := client.GetRecord(ctx, "0000-0001-5109-3700"); !errors.Is(err, orcid.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+}
+
+func TestFakeServerWorks(t *testing.T) {
+	srv := NewFakeServer()
+	defer srv.Close()
+	srv.AddRecord("0000-0002-1825-0097", testRecord())
+	for _, putCode := range []int64{11, 12} {
+		srv.AddWork("0000-0002-1825-0097", &orcid.Work{PutCode: putCode, Type: "journal-article"})
+	}
+
+	client := srv.Client()
+	defer client.Close()
+	ctx := context.Background()
+
+	works, err := client.GetWorks(ctx, "0000-0002-1825-0097")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(works.WorkGroup) != 2 {
+		t.Fatalf("Expected 2 work groups, got %d", len(works.WorkGroup))
+	}
+
+	details, err := client.GetAllWorkDetails(ctx, "0000-0002-1825-0097", 0)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(details) != 2 || details[0].PutCode != 11 || details[1].PutCode != 12 {
+		t.Errorf("Expected works 11 and 12, got %+v", details)
+	}
+
+	if _, err := client.GetWork(ctx, "0000-0002-1825-0097", "99"); !errors.Is(err, orcid.ErrNotFound) {
+		t.Errorf("Expected ErrNotFound, got %v", err)
+	}
+
+	_, err = client.GetWorksByPutCodes(ctx, "0000-0002-1825-0097", []int64{11, 99})
+	if err == nil {
+		t.Error("Expected error for missing put-code in bulk request")
+	}
+}
+
+func TestFakeServerSearch(t *testing.T) {
+	srv := NewFakeServer()
+	defer srv.Close()
+	srv.AddRecord("0000-0002-1825-0097", testRecord())
+	srv.AddRecord("0000-0001-5109-3700", &orcid.Record{})
+
+	client := srv.Client()
+	defer client.Close()
+
+	result, err := client.Search(context.Background(), orcid.SearchParams{Query: "family-name:Carberry", Rows: 1})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if result.NumFound != 2 {
+		t.Errorf("Expected 2 results found, got %d", result.NumFound)
+	}
+	if len(result.Results) != 1 || result.Results[0].OrcidIdentifier.Path != "0000-0001-5109-3700" {
+		t.Errorf("Expected first page to hold 0000-0001-5109-3700, got %+v", result.Results)
+	}
+}
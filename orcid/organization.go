@@ -0,0 +1,59 @@
+package orcid
+
+import (
+	"strings"
+	"unicode"
+)
+
+// NormalizeOrgName reduces an organization name to a form suitable for
+// comparison: lower case, "&" spelled out, punctuation removed, whitespace
+// collapsed and a leading "the" dropped.
+func NormalizeOrgName(name string) string {
+	name = strings.ToLower(strings.ReplaceAll(name, "&", " and "))
+
+	var b strings.Builder
+	for _, r := range name {
+		switch {
+		case unicode.IsLetter(r) || unicode.IsDigit(r):
+			b.WriteRune(r)
+		default:
+			b.WriteRune(' ')
+		}
+	}
+
+	words := strings.Fields(b.String())
+	if len(words) > 1 && words[0] == "the" {
+		words = words[1:]
+	}
+	return strings.Join(words, " ")
+}
+
+// MatchesDisambiguated reports whether two organizations refer to the same
+// institution. Disambiguated identifiers (ROR, GRID, Ringgold, FundRef) are
+// compared first; the normalized names are only compared when either side
+// lacks an identifier from a common source.
+func (o *Organization) MatchesDisambiguated(other *Organization) bool {
+	if o == nil || other == nil {
+		return false
+	}
+
+	a, b := o.DisambiguatedOrganization, other.DisambiguatedOrganization
+	if a != nil && b != nil && a.DisambiguatedOrganizationIdentifier != "" && b.DisambiguatedOrganizationIdentifier != "" &&
+		strings.EqualFold(a.DisambiguationSource, b.DisambiguationSource) {
+		return normalizeOrgIdentifier(a.DisambiguatedOrganizationIdentifier) == normalizeOrgIdentifier(b.DisambiguatedOrganizationIdentifier)
+	}
+
+	name := NormalizeOrgName(o.Name)
+	return name != "" && name == NormalizeOrgName(other.Name)
+}
+
+// normalizeOrgIdentifier strips any URL prefix such as "https://ror.org/" so
+// that bare and URL forms of the same identifier compare equal.
+func normalizeOrgIdentifier(id string) string {
+	id = strings.ToLower(strings.TrimSpace(id))
+	id = strings.TrimRight(id, "/")
+	if i := strings.LastIndex(id, "/"); i >= 0 {
+		id = id[i+1:]
+	}
+	return id
+}
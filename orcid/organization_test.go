@@ -0,0 +1,82 @@
+package orcid
+
+import (
+	"testing"
+)
+
+func TestNormalizeOrgName(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected string
+	}{
+		{"Massachusetts Institute of Technology", "massachusetts institute of technology"},
+		{"  The University of Oxford ", "university of oxford"},
+		{"Texas A&M University", "texas a and m university"},
+		{"Max-Planck-Gesellschaft", "max planck gesellschaft"},
+		{"", ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			result := NormalizeOrgName(tt.input)
+			if result != tt.expected {
+				t.Errorf("Expected %q, got %q", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestOrganizationMatchesDisambiguated(t *testing.T) {
+	mitRORName := &Organization{
+		Name: "MIT",
+		DisambiguatedOrganization: &DisambiguatedOrganization{
+			DisambiguatedOrganizationIdentifier: "https://ror.org/042nb2s44",
+			DisambiguationSource:                "ROR",
+		},
+	}
+	mitRORFull := &Organization{
+		Name: "Massachusetts Institute of Technology",
+		DisambiguatedOrganization: &DisambiguatedOrganization{
+			DisambiguatedOrganizationIdentifier: "042nb2s44",
+			DisambiguationSource:                "ror",
+		},
+	}
+	mitNoID := &Organization{Name: "The Massachusetts Institute of Technology"}
+	harvard := &Organization{
+		Name: "Harvard University",
+		DisambiguatedOrganization: &DisambiguatedOrganization{
+			DisambiguatedOrganizationIdentifier: "https://ror.org/03vek6s52",
+			DisambiguationSource:                "ROR",
+		},
+	}
+
+	affiliations := []*Organization{mitRORName, harvard, mitRORFull, mitNoID}
+
+	var groups [][]*Organization
+	for _, org := range affiliations {
+		placed := false
+		for i, group := range groups {
+			if group[0].MatchesDisambiguated(org) {
+				groups[i] = append(groups[i], org)
+				placed = true
+				break
+			}
+		}
+		if !placed {
+			groups = append(groups, []*Organization{org})
+		}
+	}
+
+	if len(groups) != 3 {
+		t.Fatalf("Expected 3 groups, got %d", len(groups))
+	}
+	if len(groups[0]) != 2 || groups[0][1] != mitRORFull {
+		t.Errorf("Expected MIT affiliations sharing a ROR ID to be grouped together")
+	}
+	if !mitRORFull.MatchesDisambiguated(mitNoID) {
+		t.Error("Expected name match when one side lacks an identifier")
+	}
+	if mitRORName.MatchesDisambiguated(nil) {
+		t.Error("Expected no match against nil organization")
+	}
+}
@@ -0,0 +1,35 @@
+package orcid
+
+import (
+	"strings"
+)
+
+// PublicURL returns the orcid.org page for the item the path refers to.
+// Section paths such as "/0000-0002-1825-0097/works" map to the profile page,
+// while single-item paths such as "/0000-0002-1825-0097/work/12345" keep the
+// item type and put-code. An empty string is returned for paths without an
+// ORCID iD.
+func (p Path) PublicURL(sandbox bool) string {
+	parts := strings.Split(strings.Trim(string(p), "/"), "/")
+	profile := CanonicalURL(parts[0], sandbox)
+	if profile == "" {
+		return ""
+	}
+
+	if len(parts) >= 3 && parts[2] != "" {
+		return profile + "/" + parts[1] + "/" + parts[2]
+	}
+	return profile
+}
+
+// CanonicalURL returns the profile URL of an ORCID iD, such as
+// "https://orcid.org/0000-0002-1825-0097", on the sandbox site if sandbox is
+// set. The iD may be given in any form ParseOrcidID accepts. An empty string
+// is returned if it is not a valid iD.
+func CanonicalURL(orcidID string, sandbox bool) string {
+	id, err := ResolveOrcidID(orcidID)
+	if err != nil {
+		return ""
+	}
+	return siteURL(sandbox) + "/" + id
+}
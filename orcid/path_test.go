@@ -0,0 +1,70 @@
+package orcid
+
+import (
+	"testing"
+)
+
+func TestPathPublicURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		path     Path
+		sandbox  bool
+		expected string
+	}{
+		{
+			name:     "Works section",
+			path:     Path("/0000-0002-1825-0097/works"),
+			expected: "https://orcid.org/0000-0002-1825-0097",
+		},
+		{
+			name:     "Single work",
+			path:     Path("/0000-0002-1825-0097/work/12345"),
+			expected: "https://orcid.org/0000-0002-1825-0097/work/12345",
+		},
+		{
+			name:     "Single work on sandbox",
+			path:     Path("/0000-0002-1825-0097/work/12345"),
+			sandbox:  true,
+			expected: "https://sandbox.orcid.org/0000-0002-1825-0097/work/12345",
+		},
+		{
+			name:     "Record path",
+			path:     Path("0000-0002-1825-0097"),
+			expected: "https://orcid.org/0000-0002-1825-0097",
+		},
+		{
+			name:     "No ORCID iD",
+			path:     Path("/works"),
+			expected: "",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := tt.path.PublicURL(tt.sandbox)
+			if result != tt.expected {
+				t.Errorf("Expected %s, got %s", tt.expected, result)
+			}
+		})
+	}
+}
+
+func TestCanonicalURL(t *testing.T) {
+	tests := []struct {
+		orcidID  string
+		sandbox  bool
+		expected string
+	}{
+		{"0000-0002-1825-0097", false, "https://orcid.org/0000-0002-1825-0097"},
+		{"000000021825009x", false, ""},
+		{"0000000218250097", true, "https://sandbox.orcid.org/0000-0002-1825-0097"},
+		{"https://orcid.org/0000-0002-1825-0097", true, "https://sandbox.orcid.org/0000-0002-1825-0097"},
+		{"0000-0002-1825-0098", false, ""},
+	}
+
+	for _, tt := range tests {
+		if result := CanonicalURL(tt.orcidID, tt.sandbox); result != tt.expected {
+			t.Errorf("CanonicalURL(%q, %v): expected %q, got %q", tt.orcidID, tt.sandbox, tt.expected, result)
+		}
+	}
+}
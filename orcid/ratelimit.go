@@ -0,0 +1,136 @@
+package orcid
+
+import (
+	"context"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// keyedLimiter spaces out requests that share a key, such as an ORCID iD,
+// without affecting requests for other keys.
+type keyedLimiter struct {
+	mu       sync.Mutex
+	interval time.Duration
+	next     map[string]time.Time
+}
+
+func newKeyedLimiter(requestsPerSecond int) *keyedLimiter {
+	return &keyedLimiter{
+		interval: time.Second / time.Duration(requestsPerSecond),
+		next:     make(map[string]time.Time),
+	}
+}
+
+// wait blocks until a request for key may proceed. It reports whether the
+// caller had to wait.
+func (l *keyedLimiter) wait(ctx context.Context, key string) (bool, error) {
+	l.mu.Lock()
+	now := time.Now()
+	if len(l.next) > 1024 {
+		for k, t := range l.next {
+			if t.Before(now) {
+				delete(l.next, k)
+			}
+		}
+	}
+	slot := l.next[key]
+	if slot.Before(now) {
+		slot = now
+	}
+	l.next[key] = slot.Add(l.interval)
+	l.mu.Unlock()
+
+	delay := slot.Sub(now)
+	if delay <= 0 {
+		return false, nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return true, nil
+	case <-ctx.Done():
+		return true, ctx.Err()
+	}
+}
+
+// reset discards the state kept for every key.
+func (l *keyedLimiter) reset() {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.next = make(map[string]time.Time)
+}
+
+// serverRateLimit tracks the rate limit headers of the latest response so the
+// client can slow down before the server starts rejecting requests.
+type serverRateLimit struct {
+	mu        sync.Mutex
+	known     bool
+	limit     int
+	remaining int
+	reset     time.Time
+}
+
+// update records the X-RateLimit-* headers of a response, if present. The
+// reset header may be either a Unix timestamp or a number of seconds.
+func (s *serverRateLimit) update(header http.Header) {
+	remaining, err := strconv.Atoi(header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.known = true
+	s.remaining = remaining
+	s.limit, _ = strconv.Atoi(header.Get("X-RateLimit-Limit"))
+	s.reset = time.Time{}
+	if reset, err := strconv.ParseInt(header.Get("X-RateLimit-Reset"), 10, 64); err == nil {
+		if reset > 1000000000 {
+			s.reset = time.Unix(reset, 0)
+		} else {
+			s.reset = time.Now().Add(time.Duration(reset) * time.Second)
+		}
+	}
+}
+
+// status returns the recorded headers.
+func (s *serverRateLimit) status() RateLimitStatus {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.known {
+		return RateLimitStatus{}
+	}
+	return RateLimitStatus{Limit: s.limit, Remaining: s.remaining, Reset: s.reset}
+}
+
+// RateLimitStatus is the rate limit usage ORCID reported in its X-RateLimit-*
+// response headers. Limit and Reset are zero if the server did not send them.
+type RateLimitStatus struct {
+	Limit     int
+	Remaining int
+	Reset     time.Time
+}
+
+// LastRateLimit returns the rate limit usage reported with the most recent
+// response, so that callers can slow down before the server starts answering
+// with HTTP 429. It returns the zero RateLimitStatus until a response with
+// rate limit headers has been received. It is safe to call concurrently with
+// requests.
+func (c *Client) LastRateLimit() RateLimitStatus {
+	return c.serverRateLimit.status()
+}
+
+// throttleDelay returns how long to wait before the next request when fewer
+// than threshold requests remain in the current window.
+func (s *serverRateLimit) throttleDelay(threshold int) time.Duration {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if !s.known || s.remaining >= threshold || s.reset.IsZero() {
+		return 0
+	}
+	return time.Until(s.reset)
+}
@@ -0,0 +1,231 @@
+package orcid
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"runtime"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestPerResourceRateLimit(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(0),
+		WithPerResourceRateLimit(20),
+	)
+	ctx := context.Background()
+
+	start := time.Now()
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetPerson(ctx, "0000-0002-1825-0097"); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+		}()
+	}
+
+	// Give the hot ID's requests time to queue up behind its limiter
+	time.Sleep(10 * time.Millisecond)
+
+	otherStart := time.Now()
+	if _, err := client.GetPerson(ctx, "0000-0001-5109-3700"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if elapsed := time.Since(otherStart); elapsed > 40*time.Millisecond {
+		t.Errorf("Expected request for a different ID not to wait, took %v", elapsed)
+	}
+
+	wg.Wait()
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Errorf("Expected 5 requests for one ID to take at least 200ms, took %v", elapsed)
+	}
+	if waits := client.Stats().RateLimitWaits; waits != 4 {
+		t.Errorf("Expected 4 rate limit waits, got %d", waits)
+	}
+}
+
+type roundTripFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func TestProactiveThrottle(t *testing.T) {
+	var requestTimes []time.Time
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requestTimes = append(requestTimes, time.Now())
+		header := http.Header{}
+		header.Set("Content-Type", "application/json")
+		header.Set("X-RateLimit-Limit", "24")
+		header.Set("X-RateLimit-Remaining", "1")
+		header.Set("X-RateLimit-Reset", "1")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(`{}`)),
+			Request:    req,
+		}, nil
+	})
+
+	client := NewClient(
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithAPIURL("https://pub.orcid.org/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(0),
+		WithProactiveThrottle(5),
+	)
+	ctx := context.Background()
+
+	for i := 0; i < 2; i++ {
+		if _, err := client.GetPerson(ctx, "0000-0002-1825-0097"); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	if len(requestTimes) != 2 {
+		t.Fatalf("Expected 2 requests, got %d", len(requestTimes))
+	}
+	if gap := requestTimes[1].Sub(requestTimes[0]); gap < 900*time.Millisecond {
+		t.Errorf("Expected second request to wait for the reset, gap was %v", gap)
+	}
+	if waits := client.Stats().RateLimitWaits; waits != 1 {
+		t.Errorf("Expected 1 rate limit wait, got %d", waits)
+	}
+}
+
+func TestProactiveThrottleDisabled(t *testing.T) {
+	var limit serverRateLimit
+	header := http.Header{}
+	header.Set("X-RateLimit-Remaining", "100")
+	header.Set("X-RateLimit-Reset", "60")
+	limit.update(header)
+	if delay := limit.throttleDelay(5); delay != 0 {
+		t.Errorf("Expected no delay with plenty of requests remaining, got %v", delay)
+	}
+
+	header.Set("X-RateLimit-Remaining", "2")
+	header.Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+	limit.update(header)
+	if delay := limit.throttleDelay(5); delay < 50*time.Second {
+		t.Errorf("Expected delay close to a minute for a Unix reset time, got %v", delay)
+	}
+}
+
+func TestCloseStopsRateLimiter(t *testing.T) {
+	before := runtime.NumGoroutine()
+
+	clients := make([]*Client, 50)
+	for i := range clients {
+		clients[i] = NewClient(WithRateLimit(1000), WithRateLimit(1000))
+	}
+	for _, c := range clients {
+		if err := c.Close(); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+
+	// A stopped ticker delivers no further ticks. Drain anything sent before
+	// Close and make sure nothing follows.
+	c := clients[0]
+	select {
+	case <-c.rateLimiter.C:
+	default:
+	}
+	select {
+	case <-c.rateLimiter.C:
+		t.Error("Expected no ticks after Close")
+	case <-time.After(20 * time.Millisecond):
+	}
+
+	if after := runtime.NumGoroutine(); after > before {
+		t.Errorf("Expected no goroutines to outlive Close, had %d before and %d after", before, after)
+	}
+}
+
+func TestWithRateLimitDoesNotStartTicker(t *testing.T) {
+	c := &Client{}
+	WithRateLimit(5)(c)
+	if c.rateLimiter != nil {
+		t.Error("Expected WithRateLimit to leave ticker creation to NewClient")
+	}
+	if c.rateLimit != 5 {
+		t.Errorf("Expected rateLimit %d, got %d", 5, c.rateLimit)
+	}
+}
+
+func TestLastRateLimit(t *testing.T) {
+	var remaining atomic.Int32
+	remaining.Store(24)
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		header := http.Header{}
+		header.Set("Content-Type", "application/json")
+		header.Set("X-RateLimit-Limit", "24")
+		header.Set("X-RateLimit-Remaining", strconv.Itoa(int(remaining.Add(-1))))
+		header.Set("X-RateLimit-Reset", "1700000000")
+		return &http.Response{
+			StatusCode: http.StatusOK,
+			Status:     "200 OK",
+			Header:     header,
+			Body:       io.NopCloser(strings.NewReader(`{}`)),
+			Request:    req,
+		}, nil
+	})
+
+	client := NewClient(
+		WithHTTPClient(&http.Client{Transport: transport}),
+		WithAPIURL("https://pub.orcid.org/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(0),
+	)
+	ctx := context.Background()
+
+	if status := client.LastRateLimit(); status != (RateLimitStatus{}) {
+		t.Errorf("Expected zero status before any request, got %+v", status)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, err := client.GetPerson(ctx, "0000-0002-1825-0097"); err != nil {
+				t.Errorf("Unexpected error: %v", err)
+			}
+			client.LastRateLimit()
+		}()
+	}
+	wg.Wait()
+
+	if _, err := client.GetPerson(ctx, "0000-0002-1825-0097"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	status := client.LastRateLimit()
+	if status.Limit != 24 {
+		t.Errorf("Expected limit %d, got %d", 24, status.Limit)
+	}
+	if status.Remaining != 19 {
+		t.Errorf("Expected remaining %d, got %d", 19, status.Remaining)
+	}
+	if !status.Reset.Equal(time.Unix(1700000000, 0)) {
+		t.Errorf("Expected reset %v, got %v", time.Unix(1700000000, 0), status.Reset)
+	}
+}
@@ -0,0 +1,143 @@
+package orcid
+
+import "strings"
+
+// SetPerson replaces the record's person section.
+func (r *Record) SetPerson(p *Person) {
+	if r == nil {
+		return
+	}
+	r.Person = p
+}
+
+// MergePerson fills the sub-sections of the record's person that are empty
+// with those from p, leaving sections the record already has untouched.
+func (r *Record) MergePerson(p *Person) {
+	if r == nil || p == nil {
+		return
+	}
+	if r.Person == nil {
+		r.Person = p
+		return
+	}
+
+	dst := r.Person
+	if dst.Name == nil {
+		dst.Name = p.Name
+	}
+	if dst.OtherNames == nil {
+		dst.OtherNames = p.OtherNames
+	}
+	if dst.Biography == nil {
+		dst.Biography = p.Biography
+	}
+	if dst.ResearcherURLs == nil {
+		dst.ResearcherURLs = p.ResearcherURLs
+	}
+	if dst.Emails == nil {
+		dst.Emails = p.Emails
+	}
+	if dst.Addresses == nil {
+		dst.Addresses = p.Addresses
+	}
+	if dst.Keywords == nil {
+		dst.Keywords = p.Keywords
+	}
+	if dst.ExternalIdentifiers == nil {
+		dst.ExternalIdentifiers = p.ExternalIdentifiers
+	}
+	if dst.Path == "" {
+		dst.Path = p.Path
+	}
+}
+
+// FullName returns the name of the record's owner as given by
+// Person.DisplayName, or an empty string when the record has no name.
+func (r *Record) FullName() string {
+	if r == nil {
+		return ""
+	}
+	return r.Person.DisplayName()
+}
+
+// DisplayName returns the credit name if the person set one, and otherwise
+// the given names followed by the family name. It returns an empty string
+// when the name is missing or not visible.
+func (p *Person) DisplayName() string {
+	if p == nil || p.Name == nil {
+		return ""
+	}
+	name := p.Name
+	if name.CreditName != nil {
+		if credit := strings.TrimSpace(name.CreditName.Value); credit != "" {
+			return credit
+		}
+	}
+
+	var parts []string
+	if name.GivenNames != nil && strings.TrimSpace(name.GivenNames.Value) != "" {
+		parts = append(parts, strings.TrimSpace(name.GivenNames.Value))
+	}
+	if name.FamilyName != nil && strings.TrimSpace(name.FamilyName.Value) != "" {
+		parts = append(parts, strings.TrimSpace(name.FamilyName.Value))
+	}
+	return strings.Join(parts, " ")
+}
+
+// OAuth scopes that determine which visibility levels a token can read.
+const (
+	ScopeReadPublic  = "/read-public"
+	ScopeReadLimited = "/read-limited"
+)
+
+// VisibleTo returns the emails a token with the given scope is allowed to
+// see: only public emails for ScopeReadPublic, and public and limited emails
+// for ScopeReadLimited. Private emails are never included. Note that ORCID
+// only returns limited-visibility emails at all when the request was made
+// with a member API token holding the /read-limited scope, so filtering a
+// public API response for ScopeReadLimited yields just the public ones.
+func (e *Emails) VisibleTo(scope string) []*Email {
+	if e == nil {
+		return nil
+	}
+
+	var visible []*Email
+	for _, email := range e.Email {
+		if email == nil {
+			continue
+		}
+		switch Visibility(strings.ToLower(string(email.Visibility))) {
+		case VisibilityPublic:
+			visible = append(visible, email)
+		case VisibilityLimited, "registered-only":
+			if scope == ScopeReadLimited {
+				visible = append(visible, email)
+			}
+		}
+	}
+	return visible
+}
+
+// PrimaryEmail returns the address marked primary and verified, or failing
+// that the first verified address. ok is false when the person has no
+// verified email visible to the token, which is common since most people
+// keep their emails private.
+func (p *Person) PrimaryEmail() (string, bool) {
+	if p == nil || p.Emails == nil {
+		return "", false
+	}
+
+	fallback := ""
+	for _, email := range p.Emails.Email {
+		if email == nil || !email.Verified || email.Email == "" {
+			continue
+		}
+		if email.Primary {
+			return email.Email, true
+		}
+		if fallback == "" {
+			fallback = email.Email
+		}
+	}
+	return fallback, fallback != ""
+}
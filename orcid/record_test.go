@@ -0,0 +1,144 @@
+package orcid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestRecordMergePerson(t *testing.T) {
+	record := &Record{
+		Person: &Person{
+			Name: &Name{GivenNames: &GivenNames{Value: "John"}},
+		},
+	}
+	update := &Person{
+		Name: &Name{GivenNames: &GivenNames{Value: "Johnny"}},
+		Emails: &Emails{Email: []*Email{
+			{Email: "john@example.org", Primary: true},
+		}},
+	}
+
+	record.MergePerson(update)
+
+	if record.Person.Name.GivenNames.Value != "John" {
+		t.Errorf("Expected existing name %s to be kept, got %s", "John", record.Person.Name.GivenNames.Value)
+	}
+	if record.Person.Emails == nil || len(record.Person.Emails.Email) != 1 {
+		t.Fatal("Expected emails to be merged into the record")
+	}
+	if record.Person.Emails.Email[0].Email != "john@example.org" {
+		t.Errorf("Expected email %s, got %s", "john@example.org", record.Person.Emails.Email[0].Email)
+	}
+
+	record.SetPerson(update)
+	if record.Person != update {
+		t.Error("Expected SetPerson to replace the person")
+	}
+
+	empty := &Record{}
+	empty.MergePerson(update)
+	if empty.Person != update {
+		t.Error("Expected MergePerson to set the person on a record without one")
+	}
+}
+
+func TestEmailsVisibleTo(t *testing.T) {
+	var person Person
+	err := json.Unmarshal([]byte(`{
+		"emails": {
+			"email": [
+				{"email": "public@example.org", "visibility": "public"},
+				{"email": "limited@example.org", "visibility": "limited"},
+				{"email": "private@example.org", "visibility": "private"}
+			]
+		}
+	}`), &person)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if person.Emails.Email[1].Visibility != "limited" {
+		t.Fatalf("Expected visibility %s, got %s", "limited", person.Emails.Email[1].Visibility)
+	}
+
+	public := person.Emails.VisibleTo(ScopeReadPublic)
+	if len(public) != 1 || public[0].Email != "public@example.org" {
+		t.Errorf("Expected only the public email, got %d emails", len(public))
+	}
+
+	limited := person.Emails.VisibleTo(ScopeReadLimited)
+	if len(limited) != 2 {
+		t.Fatalf("Expected 2 emails for read-limited, got %d", len(limited))
+	}
+	if limited[1].Email != "limited@example.org" {
+		t.Errorf("Expected %s, got %s", "limited@example.org", limited[1].Email)
+	}
+
+	var nilEmails *Emails
+	if nilEmails.VisibleTo(ScopeReadLimited) != nil {
+		t.Error("Expected nil result for nil emails")
+	}
+}
+
+func TestPersonPrimaryEmail(t *testing.T) {
+	tests := []struct {
+		name   string
+		person *Person
+		want   string
+		wantOK bool
+	}{
+		{"nil person", nil, "", false},
+		{"hidden emails", &Person{}, "", false},
+		{"primary verified", &Person{Emails: &Emails{Email: []*Email{
+			{Email: "other@example.org", Verified: true},
+			{Email: "primary@example.org", Primary: true, Verified: true},
+		}}}, "primary@example.org", true},
+		{"unverified primary", &Person{Emails: &Emails{Email: []*Email{
+			nil,
+			{Email: "primary@example.org", Primary: true},
+			{Email: "verified@example.org", Verified: true},
+		}}}, "verified@example.org", true},
+		{"none verified", &Person{Emails: &Emails{Email: []*Email{
+			{Email: "primary@example.org", Primary: true},
+		}}}, "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.person.PrimaryEmail()
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("Expected (%q, %v), got (%q, %v)", tt.want, tt.wantOK, got, ok)
+			}
+		})
+	}
+}
+
+func TestRecordFullName(t *testing.T) {
+	tests := []struct {
+		name   string
+		record *Record
+		want   string
+	}{
+		{"nil record", nil, ""},
+		{"no person", &Record{}, ""},
+		{"no name", &Record{Person: &Person{}}, ""},
+		{"credit name", &Record{Person: &Person{Name: &Name{
+			GivenNames: &GivenNames{Value: "Josiah"},
+			FamilyName: &FamilyName{Value: "Carberry"},
+			CreditName: &CreditName{Value: "J. S. Carberry"},
+		}}}, "J. S. Carberry"},
+		{"given and family", &Record{Person: &Person{Name: &Name{
+			GivenNames: &GivenNames{Value: "Josiah"},
+			FamilyName: &FamilyName{Value: "Carberry"},
+			CreditName: &CreditName{Value: " "},
+		}}}, "Josiah Carberry"},
+		{"given only", &Record{Person: &Person{Name: &Name{
+			GivenNames: &GivenNames{Value: "Josiah"},
+		}}}, "Josiah"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.record.FullName(); got != tt.want {
+				t.Errorf("Expected %q, got %q", tt.want, got)
+			}
+		})
+	}
+}
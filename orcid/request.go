@@ -0,0 +1,92 @@
+package orcid
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"time"
+)
+
+// RequestOption overrides a client setting for the requests made with a
+// context returned by WithRequestOptions.
+type RequestOption func(*requestOptions)
+
+type requestOptions struct {
+	timeout     time.Duration
+	contentType ContentType
+	bearerToken string
+}
+
+type requestOptionsKey struct{}
+
+// WithRequestOptions returns a context whose requests use opts in place of
+// the client's own settings, so a single call can be adjusted without
+// building another client:
+//
+//	ctx = orcid.WithRequestOptions(ctx, orcid.RequestTimeout(2*time.Minute))
+//	record, err := client.GetRecord(ctx, orcidID)
+//
+// Options already set on ctx are kept unless opts override them.
+func WithRequestOptions(ctx context.Context, opts ...RequestOption) context.Context {
+	ro := requestOptionsFrom(ctx)
+	for _, opt := range opts {
+		opt(&ro)
+	}
+	return context.WithValue(ctx, requestOptionsKey{}, ro)
+}
+
+// RequestTimeout sets the timeout of each HTTP attempt, replacing the one
+// configured with WithTimeout or WithHTTPClient.
+func RequestTimeout(timeout time.Duration) RequestOption {
+	return func(ro *requestOptions) {
+		ro.timeout = timeout
+	}
+}
+
+// RequestContentType sets the format requested from ORCID and used for
+// request bodies, for example to fetch a single record as XML from a client
+// configured for JSON.
+func RequestContentType(contentType ContentType) RequestOption {
+	return func(ro *requestOptions) {
+		ro.contentType = contentType
+	}
+}
+
+// RequestBearerToken sends token instead of the client's bearer token or
+// OAuth token, for example to act on behalf of a different ORCID user.
+func RequestBearerToken(token string) RequestOption {
+	return func(ro *requestOptions) {
+		ro.bearerToken = token
+	}
+}
+
+func requestOptionsFrom(ctx context.Context) requestOptions {
+	ro, _ := ctx.Value(requestOptionsKey{}).(requestOptions)
+	return ro
+}
+
+// contentTypeFor returns the content type for requests made with ctx.
+func (c *Client) contentTypeFor(ctx context.Context) (ContentType, error) {
+	contentType := requestOptionsFrom(ctx).contentType
+	if contentType == "" {
+		return c.contentType, nil
+	}
+	switch contentType {
+	case ContentTypeJSON, ContentTypeORCIDJSON, ContentTypeXML:
+		return contentType, nil
+	default:
+		return "", fmt.Errorf("unsupported content type: %s", contentType)
+	}
+}
+
+// httpClientFor returns the HTTP client for requests made with ctx, a copy
+// of the client's own with the timeout replaced if one was requested.
+func (c *Client) httpClientFor(ctx context.Context) *http.Client {
+	timeout := requestOptionsFrom(ctx).timeout
+	if timeout <= 0 {
+		return c.httpClient
+	}
+	httpClient := *c.httpClient
+	httpClient.Timeout = timeout
+	return &httpClient
+}
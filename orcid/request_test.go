@@ -0,0 +1,88 @@
+package orcid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRequestContentTypeOverride(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("Accept") == string(ContentTypeXML) {
+			w.Header().Set("Content-Type", string(ContentTypeXML))
+			w.Write([]byte(`<record><orcid-identifier><path>0000-0002-1825-0097</path></orcid-identifier></record>`))
+			return
+		}
+		w.Header().Set("Content-Type", string(ContentTypeJSON))
+		w.Write([]byte(`{"orcid-identifier": {"path": "0000-0002-1825-0097"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIURL(server.URL+"/v3.0"), WithBearerToken("test-token"))
+	defer client.Close()
+
+	ctx := WithRequestOptions(context.Background(), RequestContentType(ContentTypeXML))
+	record, err := client.GetRecord(ctx, "0000-0002-1825-0097")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if record.OrcidIdentifier == nil || record.OrcidIdentifier.Path != "0000-0002-1825-0097" {
+		t.Errorf("Expected record decoded from XML, got %+v", record.OrcidIdentifier)
+	}
+
+	if _, err := client.GetRecord(context.Background(), "0000-0002-1825-0097"); err != nil {
+		t.Errorf("Unexpected error for JSON request: %v", err)
+	}
+
+	ctx = WithRequestOptions(context.Background(), RequestContentType("text/plain"))
+	if _, err := client.GetRecord(ctx, "0000-0002-1825-0097"); err == nil {
+		t.Error("Expected error for unsupported content type")
+	}
+}
+
+func TestRequestBearerTokenOverride(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(WithAPIURL(server.URL+"/v3.0"), WithBearerToken("client-token"))
+	defer client.Close()
+
+	ctx := WithRequestOptions(context.Background(), RequestBearerToken("user-token"))
+	if _, err := client.GetRecord(ctx, "0000-0002-1825-0097"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if got != "Bearer user-token" {
+		t.Errorf("Expected Authorization %q, got %q", "Bearer user-token", got)
+	}
+}
+
+func TestRequestTimeoutOverride(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		time.Sleep(100 * time.Millisecond)
+		w.Write([]byte(`{}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithTimeout(20*time.Millisecond),
+		WithMaxRetries(0),
+	)
+	defer client.Close()
+
+	if _, err := client.GetRecord(context.Background(), "0000-0002-1825-0097"); err == nil {
+		t.Fatal("Expected timeout with the client's timeout")
+	}
+
+	ctx := WithRequestOptions(context.Background(), RequestTimeout(time.Second))
+	if _, err := client.GetRecord(ctx, "0000-0002-1825-0097"); err != nil {
+		t.Errorf("Unexpected error with longer request timeout: %v", err)
+	}
+}
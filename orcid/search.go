@@ -2,23 +2,38 @@ package orcid
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"io"
 	"net/http"
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
+	"time"
+	"unicode"
+	"unicode/utf8"
 )
 
 type SearchParams struct {
 	Query string
 	Start int
 	Rows  int
+	// Sort is a Solr sort clause such as "profile-submission-date desc".
+	// Several clauses may be separated by commas.
+	Sort string
+	// Cursor requests cursor-based paging instead of Start. Use "*" for the
+	// first page and SearchResult.NextCursor for the following ones.
+	Cursor string
 }
 
+// MaxSearchRows is the largest page size ORCID accepts for a search.
+const MaxSearchRows = 1000
+
 type SearchQuery struct {
 	params     SearchParams
 	queryParts []string
+	// problems are found while building and reported by Validate
+	problems []error
 }
 
 func NewSearchQuery() *SearchQuery {
@@ -31,125 +46,168 @@ func NewSearchQuery() *SearchQuery {
 }
 
 func (sq *SearchQuery) ORCID(orcid string) *SearchQuery {
-	sq.queryParts = append(sq.queryParts, fmt.Sprintf("orcid:%s", orcid))
-	return sq
+	return sq.field("orcid", orcid)
 }
 
 func (sq *SearchQuery) Email(email string) *SearchQuery {
-	sq.queryParts = append(sq.queryParts, fmt.Sprintf("email:%s", email))
-	return sq
+	return sq.field("email", email)
 }
 
 func (sq *SearchQuery) FamilyName(name string) *SearchQuery {
-	if strings.Contains(name, " ") {
-		name = fmt.Sprintf("\"%s\"", name)
-	}
-	sq.queryParts = append(sq.queryParts, fmt.Sprintf("family-name:%s", name))
-	return sq
+	return sq.field("family-name", name)
 }
 
 func (sq *SearchQuery) GivenNames(names string) *SearchQuery {
-	if strings.Contains(names, " ") {
-		names = fmt.Sprintf("\"%s\"", names)
-	}
-	sq.queryParts = append(sq.queryParts, fmt.Sprintf("given-names:%s", names))
-	return sq
+	return sq.field("given-names", names)
 }
 
 func (sq *SearchQuery) CreditName(name string) *SearchQuery {
-	if strings.Contains(name, " ") {
-		name = fmt.Sprintf("\"%s\"", name)
-	}
-	sq.queryParts = append(sq.queryParts, fmt.Sprintf("credit-name:%s", name))
-	return sq
+	return sq.field("credit-name", name)
 }
 
 func (sq *SearchQuery) OtherNames(names string) *SearchQuery {
-	if strings.Contains(names, " ") {
-		names = fmt.Sprintf("\"%s\"", names)
-	}
-	sq.queryParts = append(sq.queryParts, fmt.Sprintf("other-names:%s", names))
-	return sq
+	return sq.field("other-names", names)
 }
 
 func (sq *SearchQuery) Keyword(keyword string) *SearchQuery {
-	if strings.Contains(keyword, " ") {
-		keyword = fmt.Sprintf("\"%s\"", keyword)
-	}
-	sq.queryParts = append(sq.queryParts, fmt.Sprintf("keyword:%s", keyword))
-	return sq
+	return sq.field("keyword", keyword)
 }
 
 func (sq *SearchQuery) ExternalIdentifier(identifier string) *SearchQuery {
-	sq.queryParts = append(sq.queryParts, fmt.Sprintf("external-identifier-type-and-value:%s", identifier))
-	return sq
+	return sq.field("external-identifier-type-and-value", identifier)
 }
 
 func (sq *SearchQuery) DOI(doi string) *SearchQuery {
-	sq.queryParts = append(sq.queryParts, fmt.Sprintf("doi-self:%s", doi))
-	return sq
+	return sq.field("doi-self", doi)
 }
 
-func (sq *SearchQuery) PersonalDetails(details string) *SearchQuery {
-	if strings.Contains(details, " ") {
-		details = fmt.Sprintf("\"%s\"", details)
+// ISNI matches records with an ISNI identifier.
+func (sq *SearchQuery) ISNI(id string) *SearchQuery {
+	return sq.WorkExternalID("isni", id)
+}
+
+// ScopusID matches records with a Scopus EID.
+func (sq *SearchQuery) ScopusID(id string) *SearchQuery {
+	return sq.WorkExternalID("eid", id)
+}
+
+// ResearcherID matches records with a Web of Science ResearcherID.
+func (sq *SearchQuery) ResearcherID(id string) *SearchQuery {
+	return sq.WorkExternalID("rid", id)
+}
+
+// WorkExternalID matches records with an identifier of the given ORCID
+// identifier type, such as "pmid" or "arxiv", through its -self field.
+func (sq *SearchQuery) WorkExternalID(idType, value string) *SearchQuery {
+	idType = strings.ToLower(strings.TrimSpace(idType))
+	if idType == "" || strings.ContainsFunc(idType, func(r rune) bool {
+		return !(r >= 'a' && r <= 'z' || r >= '0' && r <= '9' || r == '-' || r == '_')
+	}) {
+		sq.problems = append(sq.problems, fmt.Errorf("invalid identifier type %q", idType))
 	}
-	sq.queryParts = append(sq.queryParts, fmt.Sprintf("personal-details:%s", details))
-	return sq
+	return sq.field(idType+"-self", value)
+}
+
+func (sq *SearchQuery) PersonalDetails(details string) *SearchQuery {
+	return sq.field("personal-details", details)
 }
 
 func (sq *SearchQuery) Biography(bio string) *SearchQuery {
-	if strings.Contains(bio, " ") {
-		bio = fmt.Sprintf("\"%s\"", bio)
-	}
-	sq.queryParts = append(sq.queryParts, fmt.Sprintf("biography:%s", bio))
-	return sq
+	return sq.field("biography", bio)
 }
 
 func (sq *SearchQuery) WorkTitle(title string) *SearchQuery {
-	if strings.Contains(title, " ") {
-		title = fmt.Sprintf("\"%s\"", title)
-	}
-	sq.queryParts = append(sq.queryParts, fmt.Sprintf("work-titles:%s", title))
-	return sq
+	return sq.field("work-titles", title)
 }
 
 func (sq *SearchQuery) FundingTitle(title string) *SearchQuery {
-	if strings.Contains(title, " ") {
-		title = fmt.Sprintf("\"%s\"", title)
-	}
-	sq.queryParts = append(sq.queryParts, fmt.Sprintf("funding-titles:%s", title))
-	return sq
+	return sq.field("funding-titles", title)
 }
 
 func (sq *SearchQuery) AffiliationOrganization(org string) *SearchQuery {
-	if strings.Contains(org, " ") {
-		org = fmt.Sprintf("\"%s\"", org)
-	}
-	sq.queryParts = append(sq.queryParts, fmt.Sprintf("affiliation-org-name:%s", org))
-	return sq
+	return sq.field("affiliation-org-name", org)
 }
 
 func (sq *SearchQuery) RINGGOLD(id string) *SearchQuery {
-	sq.queryParts = append(sq.queryParts, fmt.Sprintf("ringgold-org-id:%s", id))
-	return sq
+	return sq.field("ringgold-org-id", id)
 }
 
 func (sq *SearchQuery) GRID(id string) *SearchQuery {
-	sq.queryParts = append(sq.queryParts, fmt.Sprintf("grid-org-id:%s", id))
-	return sq
+	return sq.field("grid-org-id", id)
 }
 
 func (sq *SearchQuery) ROR(id string) *SearchQuery {
-	sq.queryParts = append(sq.queryParts, fmt.Sprintf("ror-org-id:%s", id))
-	return sq
+	return sq.field("ror-org-id", id)
 }
 
 func (sq *SearchQuery) FundRef(id string) *SearchQuery {
-	sq.queryParts = append(sq.queryParts, fmt.Sprintf("fundref-org-id:%s", id))
+	return sq.field("fundref-org-id", id)
+}
+
+// Text searches for term in ORCID's default search fields rather than a
+// specific one. The term is quoted, so it matches as a phrase.
+func (sq *SearchQuery) Text(term string) *SearchQuery {
+	if strings.TrimSpace(term) == "" {
+		sq.problems = append(sq.problems, fmt.Errorf("empty text search term"))
+	}
+	sq.queryParts = append(sq.queryParts, `"`+phraseEscaper.Replace(term)+`"`)
+	return sq
+}
+
+// ProfileSubmittedAfter matches records registered after t. Combine it with
+// ProfileSubmittedBefore through And to select a date range.
+func (sq *SearchQuery) ProfileSubmittedAfter(t time.Time) *SearchQuery {
+	sq.queryParts = append(sq.queryParts, fmt.Sprintf("profile-submission-date:{%s TO *]", solrDate(t)))
+	return sq
+}
+
+// ProfileSubmittedBefore matches records registered before t.
+func (sq *SearchQuery) ProfileSubmittedBefore(t time.Time) *SearchQuery {
+	sq.queryParts = append(sq.queryParts, fmt.Sprintf("profile-submission-date:[* TO %s}", solrDate(t)))
+	return sq
+}
+
+// solrDate formats t the way Solr expects dates in range queries.
+func solrDate(t time.Time) string {
+	return t.UTC().Format("2006-01-02T15:04:05Z")
+}
+
+// field appends a field:value clause with value escaped, so that it is
+// matched literally rather than parsed as query syntax.
+func (sq *SearchQuery) field(name, value string) *SearchQuery {
+	if strings.TrimSpace(value) == "" {
+		sq.problems = append(sq.problems, fmt.Errorf("empty value for field %s", name))
+	}
+	sq.queryParts = append(sq.queryParts, name+":"+EscapeQueryValue(value))
 	return sq
 }
 
+// EscapeQueryValue escapes the Lucene special characters in value. Values
+// containing whitespace are quoted as a phrase instead, in which only quotes
+// and backslashes need escaping.
+func EscapeQueryValue(value string) string {
+	if strings.IndexFunc(value, unicode.IsSpace) >= 0 {
+		return `"` + phraseEscaper.Replace(value) + `"`
+	}
+
+	var b strings.Builder
+	for _, r := range value {
+		if strings.ContainsRune(luceneSpecialChars, r) {
+			b.WriteByte('\\')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
+
+// luceneSpecialChars are the characters with a meaning in Lucene query
+// syntax. & and | are escaped individually, which also covers && and ||.
+const luceneSpecialChars = `+-&|!(){}[]^"~*?:\/`
+
+var phraseEscaper = strings.NewReplacer(`\`, `\\`, `"`, `\"`)
+
+// RawQuery appends query unchanged, for syntax the field methods cannot
+// express. Values in it are not escaped.
 func (sq *SearchQuery) RawQuery(query string) *SearchQuery {
 	sq.queryParts = append(sq.queryParts, query)
 	return sq
@@ -165,6 +223,37 @@ func (sq *SearchQuery) WithRows(rows int) *SearchQuery {
 	return sq
 }
 
+// SortBy orders results by field. Calling it again adds field as a tie
+// breaker for the earlier sort fields, which keeps paging stable.
+func (sq *SearchQuery) SortBy(field string, ascending bool) *SearchQuery {
+	direction := "desc"
+	if ascending {
+		direction = "asc"
+	}
+	clause := field + " " + direction
+	if sq.params.Sort != "" {
+		clause = sq.params.Sort + "," + clause
+	}
+	sq.params.Sort = clause
+	return sq
+}
+
+// Group builds a nested query with fn and appends it in parentheses, for
+// boolean nesting such as (keyword:x OR keyword:y) AND affiliation-org-name:MIT.
+// Only the query clauses of the nested query are used; its start, rows and
+// sort settings are ignored. An empty group appends nothing.
+func (sq *SearchQuery) Group(fn func(*SearchQuery)) *SearchQuery {
+	group := NewSearchQuery()
+	fn(group)
+	if err := group.validateClauses(); err != nil {
+		sq.problems = append(sq.problems, fmt.Errorf("in group: %w", err))
+	}
+	if len(group.queryParts) > 0 {
+		sq.queryParts = append(sq.queryParts, "("+strings.Join(group.queryParts, " ")+")")
+	}
+	return sq
+}
+
 func (sq *SearchQuery) And() *SearchQuery {
 	sq.queryParts = append(sq.queryParts, "AND")
 	return sq
@@ -180,6 +269,52 @@ func (sq *SearchQuery) Not() *SearchQuery {
 	return sq
 }
 
+// Validate reports mistakes in the query that ORCID would otherwise reject
+// with an unhelpful HTTP 400: boolean operators without an operand, field
+// methods given an empty value, and a page size above MaxSearchRows.
+func (sq *SearchQuery) Validate() error {
+	var errs []error
+	if err := sq.validateClauses(); err != nil {
+		errs = append(errs, err)
+	}
+	if sq.params.Rows > MaxSearchRows {
+		errs = append(errs, fmt.Errorf("rows %d exceeds the maximum of %d", sq.params.Rows, MaxSearchRows))
+	}
+	if sq.params.Rows < 0 || sq.params.Start < 0 {
+		errs = append(errs, fmt.Errorf("start and rows must not be negative"))
+	}
+	return errors.Join(errs...)
+}
+
+// validateClauses checks the query clauses, leaving out the paging settings.
+func (sq *SearchQuery) validateClauses() error {
+	errs := append([]error(nil), sq.problems...)
+	if len(sq.queryParts) == 0 {
+		errs = append(errs, fmt.Errorf("query has no clauses"))
+	}
+
+	// NOT may follow AND or OR; otherwise an operator needs a clause on
+	// both sides
+	prev := ""
+	for i, part := range sq.queryParts {
+		switch part {
+		case "AND", "OR":
+			if i == 0 || prev == "AND" || prev == "OR" || prev == "NOT" {
+				errs = append(errs, fmt.Errorf("%s at position %d is missing a left operand", part, i+1))
+			}
+		case "NOT":
+			if prev == "NOT" {
+				errs = append(errs, fmt.Errorf("NOT at position %d follows another NOT", i+1))
+			}
+		}
+		prev = part
+	}
+	if prev == "AND" || prev == "OR" || prev == "NOT" {
+		errs = append(errs, fmt.Errorf("query ends with a dangling %s", prev))
+	}
+	return errors.Join(errs...)
+}
+
 func (sq *SearchQuery) Build() SearchParams {
 	sq.params.Query = strings.Join(sq.queryParts, " ")
 	return sq.params
@@ -190,10 +325,14 @@ type SearchResult struct {
 	Start    int             `json:"start" xml:"start,attr"`
 	NumRows  int             `json:"num-rows" xml:"num-rows,attr"`
 	Results  []*SearchRecord `json:"result,omitempty" xml:"result,omitempty"`
+	// NextCursor is set for cursor-based searches and points at the next page
+	NextCursor string `json:"next-cursor,omitempty" xml:"next-cursor,attr,omitempty"`
 }
 
 type SearchRecord struct {
 	OrcidIdentifier *OrcidIdentifier `json:"orcid-identifier,omitempty" xml:"orcid-identifier,omitempty"`
+	// Score is the relevance score, when the API includes one
+	Score float64 `json:"score,omitempty" xml:"score,omitempty"`
 }
 
 func (c *Client) Search(ctx context.Context, params SearchParams) (*SearchResult, error) {
@@ -205,30 +344,103 @@ func (c *Client) Search(ctx context.Context, params SearchParams) (*SearchResult
 	}
 	defer resp.Body.Close()
 
-	data, err := io.ReadAll(resp.Body)
-	if err != nil {
-		return nil, err
-	}
-
 	var result SearchResult
-	if err := c.unmarshalResponse(data, &result); err != nil {
+	if err := c.decodeResponse(resp.Body, &result); err != nil {
 		return nil, err
 	}
 
 	return &result, nil
 }
 
+// SearchWithQuery validates query and runs it.
 func (c *Client) SearchWithQuery(ctx context.Context, query *SearchQuery) (*SearchResult, error) {
+	if err := query.Validate(); err != nil {
+		return nil, fmt.Errorf("invalid search query: %w", err)
+	}
 	params := query.Build()
 	return c.Search(ctx, params)
 }
 
+// Count returns the number of records matching query without fetching any
+// of them.
+func (c *Client) Count(ctx context.Context, query *SearchQuery) (int, error) {
+	if err := query.validateClauses(); err != nil {
+		return 0, fmt.Errorf("invalid search query: %w", err)
+	}
+	params := query.Build()
+	params.Start = 0
+	params.Cursor = ""
+
+	// buildSearchURL substitutes the default page size for zero rows
+	searchURL, err := url.Parse(c.buildSearchURL(params))
+	if err != nil {
+		return 0, err
+	}
+	values := searchURL.Query()
+	values.Set("rows", "0")
+	values.Del("sort")
+	searchURL.RawQuery = values.Encode()
+
+	resp, err := c.doRequest(ctx, http.MethodGet, searchURL.String(), nil)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	var result SearchResult
+	if err := c.decodeResponse(resp.Body, &result); err != nil {
+		return 0, err
+	}
+
+	return result.NumFound, nil
+}
+
+// DefaultBatchConcurrency is the number of queries BatchSearch runs at once.
+const DefaultBatchConcurrency = 4
+
+// BatchSearch runs the queries concurrently, at most DefaultBatchConcurrency
+// at a time and subject to the client's rate limit. The returned results and
+// errors are aligned with the queries: for each index exactly one of them is
+// non-nil.
+func (c *Client) BatchSearch(ctx context.Context, queries []*SearchQuery) ([]*SearchResult, []error) {
+	results := make([]*SearchResult, len(queries))
+	errs := make([]error, len(queries))
+
+	sem := make(chan struct{}, DefaultBatchConcurrency)
+	var wg sync.WaitGroup
+	for i, query := range queries {
+		wg.Add(1)
+		go func(i int, query *SearchQuery) {
+			defer wg.Done()
+
+			select {
+			case sem <- struct{}{}:
+				defer func() { <-sem }()
+			case <-ctx.Done():
+				errs[i] = ctx.Err()
+				return
+			}
+
+			if query == nil {
+				errs[i] = fmt.Errorf("query %d is nil", i)
+				return
+			}
+			results[i], errs[i] = c.SearchWithQuery(ctx, query)
+		}(i, query)
+	}
+	wg.Wait()
+
+	return results, errs
+}
+
 type SearchIterator struct {
 	client       *Client
 	params       SearchParams
 	currentBatch *SearchResult
 	currentIndex int
 	totalResults int
+	maxResults   int
+	yielded      int
 	ctx          context.Context
 	err          error
 }
@@ -242,15 +454,34 @@ func (c *Client) SearchIter(ctx context.Context, params SearchParams) *SearchIte
 	}
 }
 
+// SearchIterWithQuery returns an iterator over query. If the query is
+// invalid, the iterator yields nothing and Error reports why.
 func (c *Client) SearchIterWithQuery(ctx context.Context, query *SearchQuery) *SearchIterator {
 	params := query.Build()
-	return c.SearchIter(ctx, params)
+	iter := c.SearchIter(ctx, params)
+	if err := query.Validate(); err != nil {
+		iter.err = fmt.Errorf("invalid search query: %w", err)
+	}
+	return iter
+}
+
+// WithMaxResults makes Next stop after yielding n records, however many the
+// search matches, so that iterating a broad query cannot run away. A value
+// of 0 or less removes the limit. It returns si for chaining:
+//
+//	iter := client.SearchIter(ctx, params).WithMaxResults(500)
+func (si *SearchIterator) WithMaxResults(n int) *SearchIterator {
+	si.maxResults = n
+	return si
 }
 
 func (si *SearchIterator) Next() bool {
 	if si.err != nil {
 		return false
 	}
+	if si.maxResults > 0 && si.yielded >= si.maxResults {
+		return false
+	}
 
 	select {
 	case <-si.ctx.Done():
@@ -263,12 +494,12 @@ func (si *SearchIterator) Next() bool {
 	if si.currentBatch == nil || si.currentIndex >= len(si.currentBatch.Results)-1 {
 		// Check if we've fetched all available results
 		if si.currentBatch != nil {
-			// Check if we've reached the total results or no more results in current batch
-			if si.params.Start+si.params.Rows >= si.totalResults {
+			// Advance by the rows actually returned, which can be fewer
+			// than requested, so that a short page does not skip records
+			si.params.Start += len(si.currentBatch.Results)
+			if si.params.Start >= si.totalResults {
 				return false
 			}
-			// Move start position by the requested rows (not actual fetched)
-			si.params.Start += si.params.Rows
 		}
 
 		result, err := si.client.Search(si.ctx, si.params)
@@ -287,7 +518,11 @@ func (si *SearchIterator) Next() bool {
 	}
 
 	si.currentIndex++
-	return si.currentIndex < len(si.currentBatch.Results)
+	if si.currentIndex >= len(si.currentBatch.Results) {
+		return false
+	}
+	si.yielded++
+	return true
 }
 
 func (si *SearchIterator) Value() *SearchRecord {
@@ -305,28 +540,212 @@ func (si *SearchIterator) TotalResults() int {
 	return si.totalResults
 }
 
+// SearchCursorIterator walks a search using cursor-based paging, which,
+// unlike SearchIterator, is not limited by the offset ceiling of the search
+// backend.
+type SearchCursorIterator struct {
+	client       *Client
+	params       SearchParams
+	currentBatch *SearchResult
+	currentIndex int
+	totalResults int
+	done         bool
+	ctx          context.Context
+	err          error
+}
+
+// SearchCursor returns an iterator over every result of query, fetching
+// pages with the cursor parameter instead of start. Cursor paging needs a
+// stable order, so give the query a sort on a unique field with SortBy.
+func (c *Client) SearchCursor(ctx context.Context, query *SearchQuery) *SearchCursorIterator {
+	params := query.Build()
+	params.Start = 0
+	params.Cursor = "*"
+	iter := &SearchCursorIterator{
+		client:       c,
+		params:       params,
+		ctx:          ctx,
+		currentIndex: -1,
+	}
+	if err := query.Validate(); err != nil {
+		iter.err = fmt.Errorf("invalid search query: %w", err)
+	}
+	return iter
+}
+
+func (si *SearchCursorIterator) Next() bool {
+	if si.err != nil {
+		return false
+	}
+
+	select {
+	case <-si.ctx.Done():
+		si.err = si.ctx.Err()
+		return false
+	default:
+	}
+
+	for si.currentBatch == nil || si.currentIndex >= len(si.currentBatch.Results)-1 {
+		if si.done {
+			return false
+		}
+
+		result, err := si.client.Search(si.ctx, si.params)
+		if err != nil {
+			si.err = err
+			return false
+		}
+
+		si.currentBatch = result
+		si.totalResults = result.NumFound
+		si.currentIndex = -1
+
+		// The last page is the one whose next cursor does not move on
+		if result.NextCursor == "" || result.NextCursor == si.params.Cursor || len(result.Results) == 0 {
+			si.done = true
+		}
+		si.params.Cursor = result.NextCursor
+	}
+
+	si.currentIndex++
+	return true
+}
+
+func (si *SearchCursorIterator) Value() *SearchRecord {
+	if si.currentBatch == nil || si.currentIndex < 0 || si.currentIndex >= len(si.currentBatch.Results) {
+		return nil
+	}
+	return si.currentBatch.Results[si.currentIndex]
+}
+
+func (si *SearchCursorIterator) Error() error {
+	return si.err
+}
+
+func (si *SearchCursorIterator) TotalResults() int {
+	return si.totalResults
+}
+
+// ExpandedSearchParams are the parameters of an expanded search. Rows is
+// left to the server's default when zero.
+type ExpandedSearchParams struct {
+	Query string
+	Start int
+	Rows  int
+}
+
 func (c *Client) ExpandedSearch(ctx context.Context, query string) (*ExpandedSearchResult, error) {
-	searchURL := fmt.Sprintf("%s/expanded-search/?q=%s", c.apiURL, url.QueryEscape(query))
+	return c.ExpandedSearchWithParams(ctx, ExpandedSearchParams{Query: query})
+}
 
-	resp, err := c.doRequest(ctx, http.MethodGet, searchURL, nil)
-	if err != nil {
-		return nil, err
+// ExpandedSearchWithParams runs an expanded search for one page of results.
+func (c *Client) ExpandedSearchWithParams(ctx context.Context, params ExpandedSearchParams) (*ExpandedSearchResult, error) {
+	queryParams := url.Values{}
+	queryParams.Set("q", params.Query)
+	if params.Start > 0 {
+		queryParams.Set("start", strconv.Itoa(params.Start))
 	}
-	defer resp.Body.Close()
+	if params.Rows > 0 {
+		queryParams.Set("rows", strconv.Itoa(params.Rows))
+	}
+	searchURL := fmt.Sprintf("%s/expanded-search/?%s", c.apiURL, queryParams.Encode())
 
-	data, err := io.ReadAll(resp.Body)
+	resp, err := c.doRequest(ctx, http.MethodGet, searchURL, nil)
 	if err != nil {
 		return nil, err
 	}
+	defer resp.Body.Close()
 
 	var result ExpandedSearchResult
-	if err := c.unmarshalResponse(data, &result); err != nil {
+	if err := c.decodeResponse(resp.Body, &result); err != nil {
 		return nil, err
 	}
 
 	return &result, nil
 }
 
+// ExpandedSearchIterator pages through expanded search results. Each result
+// already carries names, emails and institutions, so no further request per
+// hit is needed.
+type ExpandedSearchIterator struct {
+	client       *Client
+	params       ExpandedSearchParams
+	currentBatch *ExpandedSearchResult
+	currentIndex int
+	totalResults int
+	ctx          context.Context
+	err          error
+}
+
+// ExpandedSearchIter returns an iterator over every expanded search result
+// for params, starting at params.Start and fetching params.Rows results per
+// request, or 10 if Rows is not set.
+func (c *Client) ExpandedSearchIter(ctx context.Context, params ExpandedSearchParams) *ExpandedSearchIterator {
+	if params.Rows <= 0 {
+		params.Rows = 10
+	}
+	return &ExpandedSearchIterator{
+		client:       c,
+		params:       params,
+		ctx:          ctx,
+		currentIndex: -1,
+	}
+}
+
+func (si *ExpandedSearchIterator) Next() bool {
+	if si.err != nil {
+		return false
+	}
+
+	select {
+	case <-si.ctx.Done():
+		si.err = si.ctx.Err()
+		return false
+	default:
+	}
+
+	if si.currentBatch == nil || si.currentIndex >= len(si.currentBatch.ExpandedResults)-1 {
+		if si.currentBatch != nil {
+			si.params.Start += len(si.currentBatch.ExpandedResults)
+			if si.params.Start >= si.totalResults {
+				return false
+			}
+		}
+
+		result, err := si.client.ExpandedSearchWithParams(si.ctx, si.params)
+		if err != nil {
+			si.err = err
+			return false
+		}
+
+		si.currentBatch = result
+		si.totalResults = result.NumFound
+		si.currentIndex = -1
+
+		if len(result.ExpandedResults) == 0 {
+			return false
+		}
+	}
+
+	si.currentIndex++
+	return si.currentIndex < len(si.currentBatch.ExpandedResults)
+}
+
+func (si *ExpandedSearchIterator) Value() *ExpandedSearchRecord {
+	if si.currentBatch == nil || si.currentIndex < 0 || si.currentIndex >= len(si.currentBatch.ExpandedResults) {
+		return nil
+	}
+	return si.currentBatch.ExpandedResults[si.currentIndex]
+}
+
+func (si *ExpandedSearchIterator) Error() error {
+	return si.err
+}
+
+func (si *ExpandedSearchIterator) TotalResults() int {
+	return si.totalResults
+}
+
 type ExpandedSearchResult struct {
 	NumFound        int                     `json:"num-found" xml:"num-found,attr"`
 	ExpandedResults []*ExpandedSearchRecord `json:"expanded-result,omitempty" xml:"expanded-result,omitempty"`
@@ -339,27 +758,37 @@ type ExpandedSearchRecord struct {
 	CreditName      string   `json:"credit-name,omitempty" xml:"credit-name,omitempty"`
 	Email           []string `json:"email,omitempty" xml:"email,omitempty"`
 	InstitutionName []string `json:"institution-name,omitempty" xml:"institution-name,omitempty"`
+	// Score is the relevance score, when the API includes one
+	Score float64 `json:"score,omitempty" xml:"score,omitempty"`
 }
 
+// ParseOrcidID extracts the iD from input, which may be a bare iD or a
+// profile URL such as https://orcid.org/0000-0002-1825-0097/?lang=en. The
+// result is not validated; see ValidateOrcidID.
 func ParseOrcidID(input string) string {
 	input = strings.TrimSpace(input)
 
-	if strings.HasPrefix(input, "http://") || strings.HasPrefix(input, "https://") {
-		parts := strings.Split(input, "/")
-		if len(parts) > 0 {
-			return parts[len(parts)-1]
-		}
+	// Drop any query string or fragment. Input url.Parse rejects, like a
+	// stray percent sign, is cut at the first '?' or '#' instead
+	if u, err := url.Parse(input); err == nil {
+		input = u.Path
+	} else if i := strings.IndexAny(input, "?#"); i >= 0 {
+		input = input[:i]
 	}
 
-	parts := strings.Split(input, "/")
-	return parts[len(parts)-1]
+	// Take the last path segment, so that a URL such as
+	// https://orcid.org/0000-0002-1825-0097/ yields the iD
+	input = strings.TrimRight(input, "/")
+	return input[strings.LastIndex(input, "/")+1:]
 }
 
 func FormatOrcidID(orcid string) string {
 	orcid = ParseOrcidID(orcid)
 	orcid = strings.ToUpper(strings.ReplaceAll(orcid, "-", ""))
 
-	if len(orcid) == 16 {
+	// Only hyphenate ASCII input; slicing multi-byte characters by byte
+	// offset would produce invalid UTF-8
+	if len(orcid) == 16 && utf8.RuneCountInString(orcid) == 16 {
 		return fmt.Sprintf("%s-%s-%s-%s",
 			orcid[0:4], orcid[4:8], orcid[8:12], orcid[12:16])
 	}
@@ -367,46 +796,83 @@ func FormatOrcidID(orcid string) string {
 	return orcid
 }
 
+// ValidateOrcidID checks that orcid, in any form ParseOrcidID accepts, is a
+// well-formed ORCID iD with a correct check digit. Failures are reported as
+// an *OrcidValidationError.
 func ValidateOrcidID(orcid string) error {
 	orcid = ParseOrcidID(orcid)
 	orcid = strings.ReplaceAll(orcid, "-", "")
 
 	if len(orcid) != 16 {
-		return fmt.Errorf("invalid ORCID iD length: %d", len(orcid))
+		return &OrcidValidationError{Reason: LengthError, Length: len(orcid)}
 	}
 
 	for i := 0; i < 15; i++ {
 		if orcid[i] < '0' || orcid[i] > '9' {
-			return fmt.Errorf("invalid character in ORCID iD at position %d", i)
+			return &OrcidValidationError{Reason: CharError, Length: 16, Position: i}
 		}
 	}
 
 	lastChar := orcid[15]
 	if (lastChar < '0' || lastChar > '9') && lastChar != 'X' {
-		return fmt.Errorf("invalid check digit in ORCID iD")
+		return &OrcidValidationError{Reason: CharError, Length: 16, Position: 15}
 	}
 
 	if !isValidChecksum(orcid) {
-		return fmt.Errorf("invalid ORCID iD checksum")
+		return &OrcidValidationError{Reason: ChecksumError, Length: 16}
 	}
 
 	return nil
 }
 
+// ResolveOrcidID returns the canonical form of an ORCID iD given as a bare
+// iD, a profile URL, digits without hyphens or with a lower-case check
+// character, such as "0000-0002-1694-233X". Input that is not a valid iD is
+// reported with an error wrapping the *OrcidValidationError, so callers need
+// not chain ParseOrcidID, FormatOrcidID and ValidateOrcidID themselves.
+func ResolveOrcidID(input string) (string, error) {
+	id := FormatOrcidID(input)
+	if err := ValidateOrcidID(id); err != nil {
+		return "", fmt.Errorf("%q: %w", strings.TrimSpace(input), err)
+	}
+	return id, nil
+}
+
 func isValidChecksum(orcid string) bool {
+	return string(orcid[15]) == checkDigit(orcid[:15])
+}
+
+// checkDigit computes the ISO 7064 MOD 11-2 check digit of 15 ASCII digits.
+func checkDigit(base string) string {
 	total := 0
 	for i := 0; i < 15; i++ {
-		digit, _ := strconv.Atoi(string(orcid[i]))
+		digit, _ := strconv.Atoi(string(base[i]))
 		total = (total + digit) * 2
 	}
 
 	remainder := total % 11
 	result := (12 - remainder) % 11
-
-	checkDigit := string(orcid[15])
 	if result == 10 {
-		return checkDigit == "X"
+		return "X"
 	}
-
-	return checkDigit == strconv.Itoa(result)
+	return strconv.Itoa(result)
+}
+
+// CheckDigit returns the check digit for the first 15 digits of an ORCID iD,
+// hyphens allowed, for building fixtures or minting sandbox iDs:
+//
+//	digit, _ := orcid.CheckDigit("0000-0002-1825-009") // "7"
+//
+// Input that is not 15 digits yields an *OrcidValidationError.
+func CheckDigit(baseDigits string) (string, error) {
+	base := strings.ReplaceAll(strings.TrimSpace(baseDigits), "-", "")
+	if len(base) != 15 {
+		return "", &OrcidValidationError{Reason: LengthError, Length: len(base)}
+	}
+	for i := 0; i < len(base); i++ {
+		if base[i] < '0' || base[i] > '9' {
+			return "", &OrcidValidationError{Reason: CharError, Length: len(base), Position: i}
+		}
+	}
+	return checkDigit(base), nil
 }
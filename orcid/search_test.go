@@ -0,0 +1,298 @@
+package orcid
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+)
+
+// orcidIDSeeds are inputs that have tripped up ORCID iD parsing: URLs, stray
+// separators, wrong lengths and multi-byte characters.
+var orcidIDSeeds = []string{
+	"0000-0002-1825-0097",
+	"0000000218250097",
+	"0000-0002-1694-233X",
+	"0000-0002-1694-233x",
+	"https://orcid.org/0000-0002-1825-0097",
+	"http://orcid.org/0000-0002-1825-0097/",
+	"https://sandbox.orcid.org/0000-0002-1825-0097//",
+	"https://orcid.org/0000-0002-1825-0097?lang=en",
+	"https://orcid.org/0000-0002-1825-0097/#works",
+	"orcid.org/0000-0002-1825-0097?lang=%zz",
+	"https://orcid.org/",
+	"https://",
+	"orcid.org/0000-0002-1825-0097",
+	"  0000-0002-1825-0097\n",
+	"",
+	"/",
+	"-",
+	"----------------",
+	"0000-0002-1825-009",
+	"0000-0002-1825-00977",
+	"000000021825009é",
+	"000é00021825009",
+	"ıııııııııııııııı",
+	"ǆ000000218250097",
+	"\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00\x00",
+	"\xff\xfe0000000218250097",
+}
+
+func FuzzParseOrcidID(f *testing.F) {
+	for _, seed := range orcidIDSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		id := ParseOrcidID(input)
+		if strings.Contains(id, "/") {
+			t.Errorf("ParseOrcidID(%q) = %q, which contains a slash", input, id)
+		}
+	})
+}
+
+func FuzzFormatOrcidID(f *testing.F) {
+	for _, seed := range orcidIDSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		formatted := FormatOrcidID(input)
+		if utf8.ValidString(input) && !utf8.ValidString(formatted) {
+			t.Errorf("FormatOrcidID(%q) = %q, which is not valid UTF-8", input, formatted)
+		}
+		if ValidateOrcidID(input) != nil {
+			return
+		}
+		if ValidateOrcidID(formatted) != nil {
+			t.Errorf("FormatOrcidID(%q) = %q, which does not validate", input, formatted)
+		}
+		if again := FormatOrcidID(formatted); again != formatted {
+			t.Errorf("FormatOrcidID(%q) = %q, not stable after formatting %q", formatted, again, input)
+		}
+		if parsed := ParseOrcidID(formatted); parsed != formatted {
+			t.Errorf("ParseOrcidID(%q) = %q, expected it unchanged", formatted, parsed)
+		}
+	})
+}
+
+func FuzzValidateOrcidID(f *testing.F) {
+	for _, seed := range orcidIDSeeds {
+		f.Add(seed)
+	}
+	f.Fuzz(func(t *testing.T, input string) {
+		if ValidateOrcidID(input) != nil {
+			return
+		}
+		formatted := FormatOrcidID(input)
+		if len(formatted) != 19 {
+			t.Errorf("FormatOrcidID(%q) = %q, expected 19 characters for a valid iD", input, formatted)
+		}
+		for i, r := range formatted {
+			switch {
+			case i == 4 || i == 9 || i == 14:
+				if r != '-' {
+					t.Errorf("FormatOrcidID(%q) = %q, expected hyphen at %d", input, formatted, i)
+				}
+			case i == 18:
+				if (r < '0' || r > '9') && r != 'X' {
+					t.Errorf("FormatOrcidID(%q) = %q, invalid check digit", input, formatted)
+				}
+			case r < '0' || r > '9':
+				t.Errorf("FormatOrcidID(%q) = %q, unexpected character at %d", input, formatted, i)
+			}
+		}
+	})
+}
+
+func TestEscapeQueryValue(t *testing.T) {
+	tests := []struct {
+		value    string
+		expected string
+	}{
+		{"Smith", "Smith"},
+		{"O'Brien", "O'Brien"},
+		{"Smith & Jones", `"Smith & Jones"`},
+		{"Smith&&Jones", `Smith\&\&Jones`},
+		{"Smith-Jones", `Smith\-Jones`},
+		{"a+b", `a\+b`},
+		{"(x)", `\(x\)`},
+		{"[1 TO 2]", `"[1 TO 2]"`},
+		{"wild*card?", `wild\*card\?`},
+		{"10.1000/xyz", `10.1000\/xyz`},
+		{"https://ror.org/05gq02987", `https\:\/\/ror.org\/05gq02987`},
+		{`back\slash`, `back\\slash`},
+		{`say "hi"`, `"say \"hi\""`},
+		{"!^~{}|", `\!\^\~\{\}\|`},
+	}
+
+	for _, tt := range tests {
+		if got := EscapeQueryValue(tt.value); got != tt.expected {
+			t.Errorf("EscapeQueryValue(%q) = %s, expected %s", tt.value, got, tt.expected)
+		}
+	}
+}
+
+func TestSearchQueryEscapesFieldValues(t *testing.T) {
+	params := NewSearchQuery().
+		FamilyName("O'Brien").
+		And().
+		AffiliationOrganization("Smith & Jones").
+		And().
+		Keyword("C++").
+		Or().
+		RawQuery("keyword:go*").
+		Build()
+
+	expected := `family-name:O'Brien AND affiliation-org-name:"Smith & Jones" AND keyword:C\+\+ OR keyword:go*`
+	if params.Query != expected {
+		t.Errorf("Expected query %s, got %s", expected, params.Query)
+	}
+}
+
+func TestSearchQueryGroup(t *testing.T) {
+	params := NewSearchQuery().
+		Group(func(q *SearchQuery) {
+			q.Keyword("x").Or().Keyword("y")
+		}).
+		And().
+		AffiliationOrganization("MIT").
+		Build()
+
+	expected := "(keyword:x OR keyword:y) AND affiliation-org-name:MIT"
+	if params.Query != expected {
+		t.Errorf("Expected query %s, got %s", expected, params.Query)
+	}
+
+	params = NewSearchQuery().
+		FamilyName("Smith").
+		And().
+		Not().
+		Group(func(q *SearchQuery) {
+			q.GivenNames("John").
+				Or().
+				Group(func(q *SearchQuery) {
+					q.GivenNames("Jane").And().Keyword("physics")
+				})
+		}).
+		Build()
+
+	expected = "family-name:Smith AND NOT (given-names:John OR (given-names:Jane AND keyword:physics))"
+	if params.Query != expected {
+		t.Errorf("Expected query %s, got %s", expected, params.Query)
+	}
+
+	params = NewSearchQuery().FamilyName("Smith").Group(func(*SearchQuery) {}).Build()
+	if params.Query != "family-name:Smith" {
+		t.Errorf("Expected an empty group to add nothing, got %s", params.Query)
+	}
+}
+
+func TestProfileSubmissionDateRange(t *testing.T) {
+	after := time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC)
+	before := time.Date(2021, 6, 30, 12, 0, 0, 0, time.FixedZone("CEST", 2*60*60))
+
+	params := NewSearchQuery().
+		ProfileSubmittedAfter(after).
+		And().
+		ProfileSubmittedBefore(before).
+		Build()
+
+	expected := "profile-submission-date:{2020-01-01T00:00:00Z TO *] AND profile-submission-date:[* TO 2021-06-30T10:00:00Z}"
+	if params.Query != expected {
+		t.Errorf("Expected query %s, got %s", expected, params.Query)
+	}
+}
+
+func TestSearchQueryValidate(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         *SearchQuery
+		errorContains string
+	}{
+		{"valid", NewSearchQuery().FamilyName("Smith").And().Not().GivenNames("John"), ""},
+		{"leading NOT", NewSearchQuery().Not().FamilyName("Smith"), ""},
+		{"valid group", NewSearchQuery().Group(func(q *SearchQuery) { q.Keyword("x").Or().Keyword("y") }), ""},
+		{"max rows", NewSearchQuery().FamilyName("Smith").WithRows(MaxSearchRows), ""},
+		{"trailing AND", NewSearchQuery().FamilyName("Smith").And(), "dangling AND"},
+		{"leading OR", NewSearchQuery().Or().FamilyName("Smith"), "OR at position 1 is missing a left operand"},
+		{"double operator", NewSearchQuery().FamilyName("Smith").And().Or().GivenNames("John"), "OR at position 3"},
+		{"empty value", NewSearchQuery().FamilyName(" "), "empty value for field family-name"},
+		{"empty query", NewSearchQuery(), "no clauses"},
+		{"dangling in group", NewSearchQuery().Group(func(q *SearchQuery) { q.Keyword("x").Or() }), "in group: query ends with a dangling OR"},
+		{"too many rows", NewSearchQuery().FamilyName("Smith").WithRows(MaxSearchRows + 1), "exceeds the maximum"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.query.Validate()
+			if tt.errorContains == "" {
+				if err != nil {
+					t.Errorf("Unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), tt.errorContains) {
+				t.Errorf("Expected error containing %q, got %v", tt.errorContains, err)
+			}
+		})
+	}
+}
+
+func TestSearchWithInvalidQuery(t *testing.T) {
+	client := NewClient(WithAPIURL("http://127.0.0.1:0/v3.0"), WithBearerToken("test-token"))
+	defer client.Close()
+
+	_, err := client.SearchWithQuery(context.Background(), NewSearchQuery().FamilyName("Smith").And())
+	if err == nil || !strings.Contains(err.Error(), "invalid search query") {
+		t.Errorf("Expected invalid search query error, got %v", err)
+	}
+	if requests := client.Stats().Requests; requests != 0 {
+		t.Errorf("Expected no request to be sent, got %d", requests)
+	}
+
+	iter := client.SearchIterWithQuery(context.Background(), NewSearchQuery().WithRows(5000).FamilyName("Smith"))
+	if iter.Next() {
+		t.Error("Expected an invalid query to yield no results")
+	}
+	if err := iter.Error(); err == nil || !strings.Contains(err.Error(), "exceeds the maximum") {
+		t.Errorf("Expected rows error from the iterator, got %v", err)
+	}
+}
+
+func TestSearchQueryText(t *testing.T) {
+	params := NewSearchQuery().
+		Text("Josiah Carberry").
+		Or().
+		Text(`psycho"ceramics`).
+		Build()
+
+	expected := `"Josiah Carberry" OR "psycho\"ceramics"`
+	if params.Query != expected {
+		t.Errorf("Expected query %s, got %s", expected, params.Query)
+	}
+
+	if err := NewSearchQuery().Text("").Validate(); err == nil || !strings.Contains(err.Error(), "empty text search term") {
+		t.Errorf("Expected empty term error, got %v", err)
+	}
+}
+
+func TestSearchQueryIdentifierFields(t *testing.T) {
+	params := NewSearchQuery().
+		ISNI("0000000121032683").
+		Or().
+		ScopusID("2-s2.0-85012345678").
+		Or().
+		ResearcherID("A-1234-2010").
+		Or().
+		WorkExternalID("PMID", "12345678").
+		Build()
+
+	expected := `isni-self:0000000121032683 OR eid-self:2\-s2.0\-85012345678 OR rid-self:A\-1234\-2010 OR pmid-self:12345678`
+	if params.Query != expected {
+		t.Errorf("Expected query %s, got %s", expected, params.Query)
+	}
+
+	if err := NewSearchQuery().WorkExternalID("doi self", "10.1000/1").Validate(); err == nil || !strings.Contains(err.Error(), "invalid identifier type") {
+		t.Errorf("Expected invalid identifier type error, got %v", err)
+	}
+}
@@ -0,0 +1,67 @@
+package orcid
+
+import (
+	"net/http"
+	"sync/atomic"
+)
+
+// ClientStats is a snapshot of the cumulative counters maintained by a Client.
+// The counters are updated atomically and can be exported to any metrics
+// system by polling Client.Stats.
+type ClientStats struct {
+	// Requests is the number of HTTP attempts sent, including retries.
+	Requests int64
+	// Retries is the number of attempts that were retries of an earlier one.
+	Retries int64
+	// CacheHits and CacheMisses count lookups in the response cache.
+	CacheHits   int64
+	CacheMisses int64
+	// RateLimitWaits is the number of times a request waited on the rate limiter.
+	RateLimitWaits int64
+	// NetworkErrors counts attempts that failed before a response was received.
+	NetworkErrors int64
+	// RateLimitedErrors counts HTTP 429 responses.
+	RateLimitedErrors int64
+	// ClientErrors counts other HTTP 4xx responses.
+	ClientErrors int64
+	// ServerErrors counts HTTP 5xx responses.
+	ServerErrors int64
+}
+
+type clientStats struct {
+	requests          atomic.Int64
+	retries           atomic.Int64
+	cacheHits         atomic.Int64
+	cacheMisses       atomic.Int64
+	rateLimitWaits    atomic.Int64
+	networkErrors     atomic.Int64
+	rateLimitedErrors atomic.Int64
+	clientErrors      atomic.Int64
+	serverErrors      atomic.Int64
+}
+
+// Stats returns a snapshot of the client's cumulative counters.
+func (c *Client) Stats() ClientStats {
+	return ClientStats{
+		Requests:          c.stats.requests.Load(),
+		Retries:           c.stats.retries.Load(),
+		CacheHits:         c.stats.cacheHits.Load(),
+		CacheMisses:       c.stats.cacheMisses.Load(),
+		RateLimitWaits:    c.stats.rateLimitWaits.Load(),
+		NetworkErrors:     c.stats.networkErrors.Load(),
+		RateLimitedErrors: c.stats.rateLimitedErrors.Load(),
+		ClientErrors:      c.stats.clientErrors.Load(),
+		ServerErrors:      c.stats.serverErrors.Load(),
+	}
+}
+
+func (s *clientStats) recordStatus(statusCode int) {
+	switch {
+	case statusCode == http.StatusTooManyRequests:
+		s.rateLimitedErrors.Add(1)
+	case statusCode >= 400 && statusCode < 500:
+		s.clientErrors.Add(1)
+	case statusCode >= 500 && statusCode < 600:
+		s.serverErrors.Add(1)
+	}
+}
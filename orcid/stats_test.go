@@ -0,0 +1,81 @@
+package orcid
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestClientStats(t *testing.T) {
+	var mu sync.Mutex
+	failedOnce := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.Contains(r.URL.Path, "0000-0000-0000-0404"):
+			w.WriteHeader(http.StatusNotFound)
+			return
+		case strings.Contains(r.URL.Path, "0000-0000-0000-0503"):
+			mu.Lock()
+			fail := !failedOnce
+			failedOnce = true
+			mu.Unlock()
+			if fail {
+				w.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"orcid-identifier": {"path": "0000-0002-1825-0097"}}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithRateLimit(1000),
+	)
+	ctx := context.Background()
+
+	ids := []string{
+		"0000-0002-1825-0097",
+		"0000-0002-1825-0097",
+		"0000-0002-1825-0097",
+		"0000-0000-0000-0404",
+		"0000-0000-0000-0503",
+	}
+
+	var wg sync.WaitGroup
+	for _, id := range ids {
+		wg.Add(1)
+		go func(id string) {
+			defer wg.Done()
+			client.GetRecord(ctx, id)
+		}(id)
+	}
+	wg.Wait()
+
+	stats := client.Stats()
+	if stats.Requests != 6 {
+		t.Errorf("Expected 6 requests, got %d", stats.Requests)
+	}
+	if stats.Retries != 1 {
+		t.Errorf("Expected 1 retry, got %d", stats.Retries)
+	}
+	if stats.ClientErrors != 1 {
+		t.Errorf("Expected 1 client error, got %d", stats.ClientErrors)
+	}
+	if stats.ServerErrors != 1 {
+		t.Errorf("Expected 1 server error, got %d", stats.ServerErrors)
+	}
+	if stats.NetworkErrors != 0 {
+		t.Errorf("Expected 0 network errors, got %d", stats.NetworkErrors)
+	}
+	if stats.RateLimitWaits == 0 {
+		t.Error("Expected concurrent requests to wait on the rate limiter")
+	}
+}
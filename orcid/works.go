@@ -0,0 +1,264 @@
+package orcid
+
+import (
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// CanonicalizeWorks flattens the grouped works into a single list, removes
+// duplicates that share a DOI or put-code, and sorts the result by put-code.
+// The output is independent of the group ordering returned by ORCID, which
+// makes it suitable for diffing two fetches of the same profile.
+func CanonicalizeWorks(works *Works) []*WorkSummary {
+	if works == nil {
+		return nil
+	}
+
+	byKey := make(map[string]*WorkSummary)
+	for _, group := range works.WorkGroup {
+		if group == nil {
+			continue
+		}
+		for _, summary := range group.WorkSummary {
+			if summary == nil {
+				continue
+			}
+			key := canonicalWorkKey(summary)
+			// Keep the lowest put-code so the choice does not depend on order
+			if existing, ok := byKey[key]; !ok || summary.PutCode < existing.PutCode {
+				byKey[key] = summary
+			}
+		}
+	}
+
+	result := make([]*WorkSummary, 0, len(byKey))
+	for _, summary := range byKey {
+		result = append(result, summary)
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].PutCode < result[j].PutCode
+	})
+
+	return result
+}
+
+func canonicalWorkKey(summary *WorkSummary) string {
+	if doi, ok := summary.DOI(); ok {
+		return "doi:" + doi
+	}
+	return "put-code:" + strconv.FormatInt(summary.PutCode, 10)
+}
+
+// Get returns the value of the first external ID of the given type, such as
+// "doi" or "pmid", compared case-insensitively. The value is returned as
+// recorded in ORCID.
+func (e *ExternalIDs) Get(idType string) (string, bool) {
+	if e == nil {
+		return "", false
+	}
+	for _, id := range e.ExternalID {
+		if id != nil && strings.EqualFold(id.ExternalIDType, idType) && id.ExternalIDValue != "" {
+			return id.ExternalIDValue, true
+		}
+	}
+	return "", false
+}
+
+// DOI returns the work's own DOI normalized for comparison: lower-cased and
+// without a resolver prefix such as "https://doi.org/", so it can key works
+// when deduplicating. DOIs with a relationship other than "self", such as
+// the book DOI a chapter records as "part-of", are ignored.
+func (w *WorkSummary) DOI() (string, bool) {
+	if w == nil {
+		return "", false
+	}
+	return selfDOI(w.ExternalIDs)
+}
+
+// DOI returns the work's own DOI, normalized as by WorkSummary.DOI.
+func (w *Work) DOI() (string, bool) {
+	if w == nil {
+		return "", false
+	}
+	return selfDOI(w.ExternalIDs)
+}
+
+// selfDOI returns the first normalized DOI in ids that identifies the item
+// itself.
+func selfDOI(ids *ExternalIDs) (string, bool) {
+	if ids == nil {
+		return "", false
+	}
+	for _, id := range ids.ExternalID {
+		if id != nil && id.ExternalIDValue != "" && strings.EqualFold(id.ExternalIDType, "doi") && isSelfExternalID(id) {
+			return normalizeDOI(id.ExternalIDValue), true
+		}
+	}
+	return "", false
+}
+
+// isSelfExternalID reports whether id identifies the item itself rather
+// than, say, the book a chapter is part of. ORCID leaves the relationship
+// empty on some older items, which are taken as "self".
+func isSelfExternalID(id *ExternalID) bool {
+	return id.ExternalIDRelationship == "" || strings.EqualFold(id.ExternalIDRelationship, "self")
+}
+
+// DefaultExternalIDPreference is the order in which Preferred picks an
+// external ID when no order is given.
+var DefaultExternalIDPreference = []string{"doi", "pmid"}
+
+// Preferred returns the external ID most useful for display: the first one
+// whose type appears earliest in order, compared case-insensitively, or in
+// DefaultExternalIDPreference when no order is given. When none of the
+// preferred types is present the first external ID with a value is used. The
+// URL is the one recorded in ORCID, or for DOIs and PubMed IDs a resolver
+// link when ORCID has none. ok is false if there are no external IDs.
+func (e *ExternalIDs) Preferred(order ...string) (idType, value, url string, ok bool) {
+	if e == nil {
+		return "", "", "", false
+	}
+	if len(order) == 0 {
+		order = DefaultExternalIDPreference
+	}
+
+	var chosen *ExternalID
+	for _, preferred := range order {
+		for _, id := range e.ExternalID {
+			if id != nil && id.ExternalIDValue != "" && strings.EqualFold(id.ExternalIDType, preferred) {
+				chosen = id
+				break
+			}
+		}
+		if chosen != nil {
+			break
+		}
+	}
+	if chosen == nil {
+		for _, id := range e.ExternalID {
+			if id != nil && id.ExternalIDValue != "" {
+				chosen = id
+				break
+			}
+		}
+	}
+	if chosen == nil {
+		return "", "", "", false
+	}
+
+	return chosen.ExternalIDType, chosen.ExternalIDValue, externalIDURL(chosen), true
+}
+
+func externalIDURL(id *ExternalID) string {
+	if id.ExternalIDURL != nil && id.ExternalIDURL.Value != "" {
+		return id.ExternalIDURL.Value
+	}
+	switch strings.ToLower(id.ExternalIDType) {
+	case "doi":
+		return "https://doi.org/" + normalizeDOI(id.ExternalIDValue)
+	case "pmid":
+		return "https://pubmed.ncbi.nlm.nih.gov/" + strings.TrimSpace(id.ExternalIDValue) + "/"
+	}
+	return ""
+}
+
+func normalizeDOI(doi string) string {
+	doi = strings.ToLower(strings.TrimSpace(doi))
+	for _, prefix := range []string{"https://doi.org/", "http://doi.org/", "https://dx.doi.org/", "http://dx.doi.org/", "doi:"} {
+		doi = strings.TrimPrefix(doi, prefix)
+	}
+	return doi
+}
+
+// ORCIDID returns the contributor's ORCID iD in its canonical hyphenated
+// form, taken from the contributor-orcid path or URI. It returns an empty
+// string when the contributor has no valid ORCID reference.
+func (c *Contributor) ORCIDID() string {
+	if c == nil || c.ContributorOrcid == nil {
+		return ""
+	}
+
+	for _, candidate := range []string{string(c.ContributorOrcid.Path), c.ContributorOrcid.URI} {
+		if candidate != "" && ValidateOrcidID(candidate) == nil {
+			return FormatOrcidID(candidate)
+		}
+	}
+	return ""
+}
+
+// MatchedWork is a work listed in two works responses, together with the
+// normalized external IDs ("type:value") they were matched on.
+type MatchedWork struct {
+	A          *WorkSummary
+	B          *WorkSummary
+	MatchedIDs []string
+}
+
+// sharedWorkIDTypes are the external ID types used to match works across
+// records.
+var sharedWorkIDTypes = []string{"doi", "pmid"}
+
+// SharedWorks returns the works that appear in both a and b, matched on their
+// DOI or PubMed ID. Only identifiers with a "self" relationship are used, so
+// that, for example, two chapters sharing a book DOI are not matched.
+func SharedWorks(a, b *Works) []MatchedWork {
+	index := make(map[string]*WorkSummary)
+	for _, summary := range CanonicalizeWorks(b) {
+		for _, key := range sharedWorkKeys(summary) {
+			if _, ok := index[key]; !ok {
+				index[key] = summary
+			}
+		}
+	}
+
+	var matches []MatchedWork
+	for _, summary := range CanonicalizeWorks(a) {
+		var match *MatchedWork
+		for _, key := range sharedWorkKeys(summary) {
+			other, ok := index[key]
+			if !ok {
+				continue
+			}
+			if match == nil {
+				match = &MatchedWork{A: summary, B: other}
+			} else if match.B != other {
+				continue
+			}
+			match.MatchedIDs = append(match.MatchedIDs, key)
+		}
+		if match != nil {
+			matches = append(matches, *match)
+		}
+	}
+	return matches
+}
+
+func sharedWorkKeys(summary *WorkSummary) []string {
+	if summary.ExternalIDs == nil {
+		return nil
+	}
+
+	var keys []string
+	for _, id := range summary.ExternalIDs.ExternalID {
+		if id == nil || id.ExternalIDValue == "" {
+			continue
+		}
+		if !isSelfExternalID(id) {
+			continue
+		}
+		idType := strings.ToLower(id.ExternalIDType)
+		for _, t := range sharedWorkIDTypes {
+			if idType != t {
+				continue
+			}
+			value := strings.ToLower(strings.TrimSpace(id.ExternalIDValue))
+			if idType == "doi" {
+				value = normalizeDOI(value)
+			}
+			keys = append(keys, idType+":"+value)
+		}
+	}
+	return keys
+}
@@ -0,0 +1,233 @@
+package orcid
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCanonicalizeWorks(t *testing.T) {
+	first := `{
+		"group": [
+			{"work-summary": [
+				{"put-code": 30, "title": {"title": {"value": "Third"}}}
+			]},
+			{"work-summary": [
+				{"put-code": 20, "external-ids": {"external-id": [{"external-id-type": "doi", "external-id-value": "10.1000/ABC"}]}},
+				{"put-code": 10, "external-ids": {"external-id": [{"external-id-type": "doi", "external-id-value": "https://doi.org/10.1000/abc"}]}}
+			]}
+		]
+	}`
+	second := `{
+		"group": [
+			{"work-summary": [
+				{"put-code": 10, "external-ids": {"external-id": [{"external-id-type": "doi", "external-id-value": "https://doi.org/10.1000/abc"}]}}
+			]},
+			{"work-summary": [
+				{"put-code": 30, "title": {"title": {"value": "Third"}}}
+			]},
+			{"work-summary": [
+				{"put-code": 20, "external-ids": {"external-id": [{"external-id-type": "doi", "external-id-value": "10.1000/ABC"}]}}
+			]}
+		]
+	}`
+
+	var worksA, worksB Works
+	if err := json.Unmarshal([]byte(first), &worksA); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := json.Unmarshal([]byte(second), &worksB); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	canonicalA := CanonicalizeWorks(&worksA)
+	canonicalB := CanonicalizeWorks(&worksB)
+
+	if len(canonicalA) != 2 {
+		t.Fatalf("Expected 2 canonical works, got %d", len(canonicalA))
+	}
+	if len(canonicalA) != len(canonicalB) {
+		t.Fatalf("Expected equal lengths, got %d and %d", len(canonicalA), len(canonicalB))
+	}
+	for i := range canonicalA {
+		if canonicalA[i].PutCode != canonicalB[i].PutCode {
+			t.Errorf("Expected put-code %d at index %d, got %d", canonicalA[i].PutCode, i, canonicalB[i].PutCode)
+		}
+	}
+	if canonicalA[0].PutCode != 10 || canonicalA[1].PutCode != 30 {
+		t.Errorf("Expected put-codes [10 30], got [%d %d]", canonicalA[0].PutCode, canonicalA[1].PutCode)
+	}
+
+	if CanonicalizeWorks(nil) != nil {
+		t.Error("Expected nil result for nil works")
+	}
+}
+
+func TestContributorORCIDID(t *testing.T) {
+	withURI := &Contributor{
+		ContributorOrcid: &ContributorOrcid{
+			URI:  "https://orcid.org/0000-0002-1825-0097",
+			Host: "orcid.org",
+		},
+	}
+	if id := withURI.ORCIDID(); id != "0000-0002-1825-0097" {
+		t.Errorf("Expected %s, got %s", "0000-0002-1825-0097", id)
+	}
+
+	withoutORCID := &Contributor{CreditName: &CreditName{Value: "J. Doe"}}
+	if id := withoutORCID.ORCIDID(); id != "" {
+		t.Errorf("Expected empty ID, got %s", id)
+	}
+
+	var nilContributor *Contributor
+	if id := nilContributor.ORCIDID(); id != "" {
+		t.Errorf("Expected empty ID for nil contributor, got %s", id)
+	}
+}
+
+func TestSharedWorks(t *testing.T) {
+	var worksA, worksB Works
+	err := json.Unmarshal([]byte(`{
+		"group": [
+			{"work-summary": [{"put-code": 1, "external-ids": {"external-id": [
+				{"external-id-type": "doi", "external-id-value": "10.1000/shared", "external-id-relationship": "self"}
+			]}}]},
+			{"work-summary": [{"put-code": 2, "external-ids": {"external-id": [
+				{"external-id-type": "doi", "external-id-value": "10.1000/only-a", "external-id-relationship": "self"}
+			]}}]},
+			{"work-summary": [{"put-code": 3, "external-ids": {"external-id": [
+				{"external-id-type": "doi", "external-id-value": "10.1000/book", "external-id-relationship": "part-of"}
+			]}}]}
+		]
+	}`), &worksA)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	err = json.Unmarshal([]byte(`{
+		"group": [
+			{"work-summary": [{"put-code": 10, "external-ids": {"external-id": [
+				{"external-id-type": "DOI", "external-id-value": "https://doi.org/10.1000/SHARED", "external-id-relationship": "self"}
+			]}}]},
+			{"work-summary": [{"put-code": 11, "external-ids": {"external-id": [
+				{"external-id-type": "doi", "external-id-value": "10.1000/book", "external-id-relationship": "part-of"}
+			]}}]}
+		]
+	}`), &worksB)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	matches := SharedWorks(&worksA, &worksB)
+	if len(matches) != 1 {
+		t.Fatalf("Expected 1 matched work, got %d", len(matches))
+	}
+	if matches[0].A.PutCode != 1 || matches[0].B.PutCode != 10 {
+		t.Errorf("Expected put-codes 1 and 10, got %d and %d", matches[0].A.PutCode, matches[0].B.PutCode)
+	}
+	if len(matches[0].MatchedIDs) != 1 || matches[0].MatchedIDs[0] != "doi:10.1000/shared" {
+		t.Errorf("Expected matched ID doi:10.1000/shared, got %v", matches[0].MatchedIDs)
+	}
+}
+
+func TestExternalIDsPreferred(t *testing.T) {
+	var work WorkSummary
+	err := json.Unmarshal([]byte(`{"external-ids": {"external-id": [
+		{"external-id-type": "pmid", "external-id-value": "12345678"},
+		{"external-id-type": "doi", "external-id-value": "10.1000/XYZ"}
+	]}}`), &work)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	idType, value, url, ok := work.ExternalIDs.Preferred()
+	if !ok {
+		t.Fatal("Expected an external ID")
+	}
+	if idType != "doi" || value != "10.1000/XYZ" {
+		t.Errorf("Expected doi 10.1000/XYZ, got %s %s", idType, value)
+	}
+	if url != "https://doi.org/10.1000/xyz" {
+		t.Errorf("Expected URL %s, got %s", "https://doi.org/10.1000/xyz", url)
+	}
+
+	idType, _, url, _ = work.ExternalIDs.Preferred("PMID", "doi")
+	if idType != "pmid" || url != "https://pubmed.ncbi.nlm.nih.gov/12345678/" {
+		t.Errorf("Expected pmid with PubMed URL, got %s %s", idType, url)
+	}
+
+	var wosOnly WorkSummary
+	err = json.Unmarshal([]byte(`{"external-ids": {"external-id": [
+		{"external-id-type": "wosuid", "external-id-value": "WOS:000123456700001", "external-id-url": {"value": "https://example.org/wos"}}
+	]}}`), &wosOnly)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	idType, value, url, ok = wosOnly.ExternalIDs.Preferred()
+	if !ok {
+		t.Fatal("Expected an external ID")
+	}
+	if idType != "wosuid" || value != "WOS:000123456700001" || url != "https://example.org/wos" {
+		t.Errorf("Expected wosuid with its recorded URL, got %s %s %s", idType, value, url)
+	}
+
+	var none *ExternalIDs
+	if _, _, _, ok := none.Preferred(); ok {
+		t.Error("Expected no external ID for nil ExternalIDs")
+	}
+}
+
+func TestExternalIDsGet(t *testing.T) {
+	ids := &ExternalIDs{ExternalID: []*ExternalID{
+		nil,
+		{ExternalIDType: "pmid", ExternalIDValue: ""},
+		{ExternalIDType: "PMID", ExternalIDValue: "12345"},
+		{ExternalIDType: "doi", ExternalIDValue: "https://doi.org/10.1000/ABC"},
+	}}
+
+	if value, ok := ids.Get("pmid"); !ok || value != "12345" {
+		t.Errorf("Expected (12345, true), got (%q, %v)", value, ok)
+	}
+	if _, ok := ids.Get("isbn"); ok {
+		t.Error("Expected no isbn")
+	}
+	var nilIDs *ExternalIDs
+	if _, ok := nilIDs.Get("doi"); ok {
+		t.Error("Expected no value for nil external IDs")
+	}
+
+	summary := &WorkSummary{ExternalIDs: ids}
+	if doi, ok := summary.DOI(); !ok || doi != "10.1000/abc" {
+		t.Errorf("Expected normalized DOI 10.1000/abc, got (%q, %v)", doi, ok)
+	}
+	if _, ok := (&WorkSummary{}).DOI(); ok {
+		t.Error("Expected no DOI for a work without external IDs")
+	}
+	if doi, ok := (&Work{ExternalIDs: ids}).DOI(); !ok || doi != "10.1000/abc" {
+		t.Errorf("Expected normalized DOI 10.1000/abc for a full work, got (%q, %v)", doi, ok)
+	}
+}
+
+func TestPartOfDOIIgnored(t *testing.T) {
+	chapter := func(putCode int64, chapterDOI string) *WorkSummary {
+		ids := []*ExternalID{{ExternalIDType: "doi", ExternalIDValue: "10.1000/book", ExternalIDRelationship: "part-of"}}
+		if chapterDOI != "" {
+			ids = append(ids, &ExternalID{ExternalIDType: "doi", ExternalIDValue: chapterDOI, ExternalIDRelationship: "self"})
+		}
+		return &WorkSummary{PutCode: putCode, Type: WorkTypeBookChapter, ExternalIDs: &ExternalIDs{ExternalID: ids}}
+	}
+
+	if doi, ok := chapter(1, "10.1000/book.ch1").DOI(); !ok || doi != "10.1000/book.ch1" {
+		t.Errorf("Expected the chapter's own DOI, got (%q, %v)", doi, ok)
+	}
+	if doi, ok := chapter(1, "").DOI(); ok {
+		t.Errorf("Expected no DOI for a chapter with only a part-of DOI, got %q", doi)
+	}
+
+	works := &Works{WorkGroup: []*WorkGroup{
+		{WorkSummary: []*WorkSummary{chapter(1, "")}},
+		{WorkSummary: []*WorkSummary{chapter(2, "")}},
+	}}
+	if canonical := CanonicalizeWorks(works); len(canonical) != 2 {
+		t.Errorf("Expected chapters sharing a book DOI to stay distinct, got %d works", len(canonical))
+	}
+}
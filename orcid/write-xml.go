@@ -0,0 +1,230 @@
+package orcid
+
+import (
+	"bytes"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// XML namespaces used by the ORCID v3.0 message schema.
+const (
+	NamespaceCommon     = "http://www.orcid.org/ns/common"
+	NamespaceWork       = "http://www.orcid.org/ns/work"
+	NamespaceEducation  = "http://www.orcid.org/ns/education"
+	NamespaceEmployment = "http://www.orcid.org/ns/employment"
+	NamespaceFunding    = "http://www.orcid.org/ns/funding"
+	NamespaceBulk       = "http://www.orcid.org/ns/bulk"
+)
+
+var errNoORCIDXML = errors.New("no ORCID XML mapping")
+
+// xmlSchema describes how one activity type is written in ORCID's XML.
+type xmlSchema struct {
+	prefix    string
+	namespace string
+	// order lists the elements allowed directly under the root, in the order
+	// the schema requires. Others, such as server-assigned fields, are left out
+	order []string
+	// common holds the paths below the root of elements in the common
+	// namespace; their descendants are common too. Every other element takes
+	// the activity's prefix
+	common []string
+}
+
+var (
+	workSchema = &xmlSchema{
+		prefix:    "work",
+		namespace: NamespaceWork,
+		order: []string{"title", "journal-title", "short-description", "citation", "type",
+			"publication-date", "external-ids", "url", "contributors", "language-code", "country"},
+		common: []string{"title/title", "title/subtitle", "title/translated-title", "publication-date",
+			"external-ids", "language-code", "country", "contributors/contributor/contributor-orcid"},
+	}
+	fundingSchema = &xmlSchema{
+		prefix:    "funding",
+		namespace: NamespaceFunding,
+		order: []string{"type", "organization-defined-type", "title", "short-description", "amount",
+			"url", "start-date", "end-date", "external-ids", "contributors", "organization"},
+		common: []string{"title/title", "title/translated-title", "url", "start-date", "end-date",
+			"external-ids", "organization", "contributors/contributor/contributor-orcid"},
+	}
+)
+
+func affiliationSchema(prefix, namespace string) *xmlSchema {
+	elements := []string{"department-name", "role-title", "start-date", "end-date",
+		"organization", "url", "external-ids"}
+	return &xmlSchema{prefix: prefix, namespace: namespace, order: elements, common: elements}
+}
+
+// MarshalORCIDXML encodes a Work, EducationSummary, EmploymentSummary,
+// FundingSummary or WorkBulk as the namespaced XML ORCID's member API
+// expects for writes, such as a work:work root with common:external-ids. The
+// package's XML struct tags only serve reading, so xml.Marshal does not
+// produce a document ORCID accepts. Server-assigned fields like the source
+// and dates are left out, as are empty elements.
+//
+// Clients configured with ContentTypeXML use this for their write methods.
+func MarshalORCIDXML(v interface{}) ([]byte, error) {
+	var buf bytes.Buffer
+	buf.WriteString(xml.Header)
+
+	var err error
+	switch v := v.(type) {
+	case *Work:
+		err = writeORCIDXML(&buf, v, "work", workSchema, true)
+	case *EducationSummary:
+		err = writeORCIDXML(&buf, v, "education", affiliationSchema("education", NamespaceEducation), true)
+	case *EmploymentSummary:
+		err = writeORCIDXML(&buf, v, "employment", affiliationSchema("employment", NamespaceEmployment), true)
+	case *FundingSummary:
+		err = writeORCIDXML(&buf, v, "funding", fundingSchema, true)
+	case *WorkBulk:
+		fmt.Fprintf(&buf, `<bulk:bulk xmlns:bulk="%s" xmlns:work="%s" xmlns:common="%s">`,
+			NamespaceBulk, NamespaceWork, NamespaceCommon)
+		for _, item := range v.Bulk {
+			if item == nil || item.Work == nil {
+				continue
+			}
+			if err = writeORCIDXML(&buf, item.Work, "work", workSchema, false); err != nil {
+				break
+			}
+		}
+		buf.WriteString("</bulk:bulk>")
+	default:
+		return nil, fmt.Errorf("%w for %T", errNoORCIDXML, v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// writeORCIDXML writes v as the root element prefix:name, renaming the
+// elements produced by the struct tags according to schema.
+func writeORCIDXML(buf *bytes.Buffer, v interface{}, name string, schema *xmlSchema, declare bool) error {
+	data, err := xml.Marshal(v)
+	if err != nil {
+		return err
+	}
+	root, err := parseXMLTree(data)
+	if err != nil {
+		return err
+	}
+
+	var children []*xmlNode
+	for _, child := range root.children {
+		if slices.Contains(schema.order, child.name) {
+			children = append(children, child)
+		}
+	}
+	slices.SortStableFunc(children, func(a, b *xmlNode) int {
+		return slices.Index(schema.order, a.name) - slices.Index(schema.order, b.name)
+	})
+
+	qname := schema.prefix + ":" + name
+	buf.WriteString("<" + qname)
+	if declare {
+		fmt.Fprintf(buf, ` xmlns:%s="%s" xmlns:common="%s"`, schema.prefix, schema.namespace, NamespaceCommon)
+	}
+	for _, attr := range root.attrs {
+		if attr.Name.Local != "path" {
+			writeXMLAttr(buf, attr)
+		}
+	}
+	buf.WriteString(">")
+	for _, child := range children {
+		writeXMLNode(buf, child, child.name, schema, false)
+	}
+	buf.WriteString("</" + qname + ">")
+	return nil
+}
+
+func writeXMLNode(buf *bytes.Buffer, n *xmlNode, path string, schema *xmlSchema, common bool) {
+	if n.empty() {
+		return
+	}
+	common = common || slices.Contains(schema.common, path)
+	prefix := schema.prefix
+	if common {
+		prefix = "common"
+	}
+
+	qname := prefix + ":" + n.name
+	buf.WriteString("<" + qname)
+	for _, attr := range n.attrs {
+		writeXMLAttr(buf, attr)
+	}
+	buf.WriteString(">")
+	xml.EscapeText(buf, []byte(n.text))
+	for _, child := range n.children {
+		writeXMLNode(buf, child, path+"/"+child.name, schema, common)
+	}
+	buf.WriteString("</" + qname + ">")
+}
+
+func writeXMLAttr(buf *bytes.Buffer, attr xml.Attr) {
+	buf.WriteString(" " + attr.Name.Local + `="`)
+	xml.EscapeText(buf, []byte(attr.Value))
+	buf.WriteString(`"`)
+}
+
+// xmlNode is a parsed element of the plain XML produced by the struct tags.
+type xmlNode struct {
+	name     string
+	attrs    []xml.Attr
+	text     string
+	children []*xmlNode
+}
+
+// empty reports whether n carries no content, like the empty journal-title
+// emitted for a Work without one.
+func (n *xmlNode) empty() bool {
+	if n.text != "" || len(n.attrs) > 0 {
+		return false
+	}
+	for _, child := range n.children {
+		if !child.empty() {
+			return false
+		}
+	}
+	return true
+}
+
+func parseXMLTree(data []byte) (*xmlNode, error) {
+	decoder := xml.NewDecoder(bytes.NewReader(data))
+	var stack []*xmlNode
+	for {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		switch t := token.(type) {
+		case xml.StartElement:
+			n := &xmlNode{name: t.Name.Local, attrs: t.Attr}
+			if len(stack) > 0 {
+				parent := stack[len(stack)-1]
+				parent.children = append(parent.children, n)
+			}
+			stack = append(stack, n)
+		case xml.CharData:
+			if len(stack) > 0 {
+				stack[len(stack)-1].text += strings.TrimSpace(string(t))
+			}
+		case xml.EndElement:
+			n := stack[len(stack)-1]
+			// Values modelled as {"value": ...} for JSON, such as the
+			// journal title, are plain text in ORCID's XML
+			if len(n.children) == 1 && n.children[0].name == "value" && len(n.children[0].children) == 0 {
+				n.text = n.children[0].text
+				n.children = nil
+			}
+			stack = stack[:len(stack)-1]
+			if len(stack) == 0 {
+				return n, nil
+			}
+		}
+	}
+}
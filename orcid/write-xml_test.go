@@ -0,0 +1,119 @@
+package orcid
+
+import (
+	"context"
+	"encoding/xml"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func testXMLWork() *Work {
+	return &Work{
+		PutCode:      5,
+		Title:        &Title{Title: &TitleValue{Value: "Graphs & Trees"}},
+		JournalTitle: JournalTitle{Value: "Journal of Examples"},
+		Type:         "journal-article",
+		PublicationDate: &PublicationDate{
+			Year: &Year{Value: "2020"},
+		},
+		ExternalIDs: &ExternalIDs{ExternalID: []*ExternalID{
+			{ExternalIDType: "doi", ExternalIDValue: "10.1000/xyz", ExternalIDRelationship: "self"},
+		}},
+		Contributors: &Contributors{Contributor: []*Contributor{{
+			CreditName:            &CreditName{Value: "Josiah Carberry"},
+			ContributorAttributes: &ContributorAttributes{ContributorSequence: "first", ContributorRole: "author"},
+		}}},
+		Source:     &Source{},
+		Visibility: "public",
+	}
+}
+
+func TestMarshalORCIDXMLWork(t *testing.T) {
+	data, err := MarshalORCIDXML(testXMLWork())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	doc := string(data)
+
+	for _, want := range []string{
+		`<work:work xmlns:work="` + NamespaceWork + `" xmlns:common="` + NamespaceCommon + `" put-code="5" visibility="public">`,
+		`<work:title><common:title>Graphs &amp; Trees</common:title></work:title>`,
+		`<work:journal-title>Journal of Examples</work:journal-title>`,
+		`<common:publication-date><common:year>2020</common:year></common:publication-date>`,
+		`<common:external-ids><common:external-id><common:external-id-type>doi</common:external-id-type>`,
+		`<work:contributor><work:credit-name>Josiah Carberry</work:credit-name>`,
+	} {
+		if !strings.Contains(doc, want) {
+			t.Errorf("Expected %s in\n%s", want, doc)
+		}
+	}
+	if strings.Contains(doc, "source") {
+		t.Errorf("Expected the source to be left out, got\n%s", doc)
+	}
+
+	var decoded Work
+	if err := xml.Unmarshal(data, &decoded); err != nil {
+		t.Fatalf("Unexpected error decoding: %v", err)
+	}
+	if decoded.Title.Title.Value != "Graphs & Trees" || decoded.ExternalIDs.ExternalID[0].ExternalIDValue != "10.1000/xyz" {
+		t.Errorf("Expected the document to read back, got %+v", decoded)
+	}
+}
+
+func TestMarshalORCIDXMLFundingOrder(t *testing.T) {
+	funding := &FundingSummary{
+		Title:        &Title{Title: &TitleValue{Value: "Example Grant"}},
+		Type:         "grant",
+		Organization: &Organization{Name: "Example Foundation"},
+		URL:          &URL{Value: "https://example.org/grant"},
+	}
+	data, err := MarshalORCIDXML(funding)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	doc := string(data)
+
+	order := []string{"<funding:type>", "<funding:title>", "<common:url>", "<common:organization>"}
+	last := -1
+	for _, element := range order {
+		i := strings.Index(doc, element)
+		if i <= last {
+			t.Errorf("Expected %s after the previous elements in\n%s", element, doc)
+		}
+		last = i
+	}
+}
+
+func TestMarshalORCIDXMLUnsupported(t *testing.T) {
+	if _, err := MarshalORCIDXML(&Keywords{}); err == nil {
+		t.Error("Expected error for a type without an ORCID XML mapping")
+	}
+}
+
+func TestAddWorkXML(t *testing.T) {
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		body = string(data)
+		w.Header().Set("Location", "https://api.orcid.org/v3.0/0000-0002-1825-0097/work/123")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithContentType(ContentTypeXML),
+	)
+	defer client.Close()
+
+	if _, err := client.AddWork(context.Background(), "0000-0002-1825-0097", testXMLWork()); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !strings.Contains(body, "<work:work ") || strings.Contains(body, "put-code") {
+		t.Errorf("Expected a namespaced work without put-code, got\n%s", body)
+	}
+}
@@ -0,0 +1,481 @@
+package orcid
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+// parsePutCodeFromLocation extracts the put-code from the Location header
+// returned by ORCID when an item is created. The header is normally a full
+// URL ending in the put-code, but a trailing slash, query string or fragment
+// are tolerated.
+func parsePutCodeFromLocation(loc string) (int64, error) {
+	loc = strings.TrimSpace(loc)
+	if loc == "" {
+		return 0, fmt.Errorf("missing Location header in response")
+	}
+
+	u, err := url.Parse(loc)
+	if err != nil {
+		return 0, fmt.Errorf("malformed Location header %q: %w", loc, err)
+	}
+
+	path := strings.TrimRight(u.Path, "/")
+	segment := path[strings.LastIndex(path, "/")+1:]
+	if segment == "" {
+		return 0, fmt.Errorf("malformed Location header %q: no put-code found", loc)
+	}
+
+	putCode, err := strconv.ParseInt(segment, 10, 64)
+	if err != nil || putCode <= 0 {
+		return 0, fmt.Errorf("malformed Location header %q: invalid put-code %q", loc, segment)
+	}
+
+	return putCode, nil
+}
+
+// AddWork creates a work on the record through the member API and returns
+// the put-code ORCID assigned to it. Validation failures are returned with
+// ORCID's error body.
+func (c *Client) AddWork(ctx context.Context, orcidID string, work *Work) (int64, error) {
+	if work == nil {
+		return 0, fmt.Errorf("work is required")
+	}
+
+	body := work.forWrite()
+	body.PutCode = 0
+
+	url := fmt.Sprintf("%s/%s/work", c.apiURL, orcidID)
+	return c.createItem(ctx, url, body)
+}
+
+// AddEducation creates an education affiliation on the record through the
+// member API and returns the put-code ORCID assigned to it. Server-assigned
+// fields such as the source and dates are not sent.
+func (c *Client) AddEducation(ctx context.Context, orcidID string, edu *EducationSummary) (int64, error) {
+	if edu == nil {
+		return 0, fmt.Errorf("education is required")
+	}
+
+	body := edu.forWrite()
+	body.PutCode = 0
+
+	url := fmt.Sprintf("%s/%s/education", c.apiURL, orcidID)
+	return c.createItem(ctx, url, body)
+}
+
+// forWrite returns a copy of the work without the fields ORCID assigns
+// itself, which it rejects or ignores when they are sent.
+func (w *Work) forWrite() *Work {
+	body := *w
+	body.CreatedDate = nil
+	body.LastModifiedDate = nil
+	body.Source = nil
+	body.Path = ""
+	return &body
+}
+
+// forWrite returns a copy of the education without server-assigned fields.
+func (e *EducationSummary) forWrite() *EducationSummary {
+	body := *e
+	body.CreatedDate = nil
+	body.LastModifiedDate = nil
+	body.Source = nil
+	body.Path = ""
+	return &body
+}
+
+// BulkWorkResult reports the outcome of AddWorks. Items are aligned with the
+// submitted works.
+type BulkWorkResult struct {
+	Items []BulkWorkItem
+}
+
+// BulkWorkItem pairs a submitted work with the put-code ORCID assigned to it,
+// or with the error ORCID reported for it.
+type BulkWorkItem struct {
+	Work    *Work
+	PutCode int64
+	Err     error
+}
+
+// Failed returns the items that were not created.
+func (r *BulkWorkResult) Failed() []BulkWorkItem {
+	var failed []BulkWorkItem
+	for _, item := range r.Items {
+		if item.Err != nil {
+			failed = append(failed, item)
+		}
+	}
+	return failed
+}
+
+// AddWorks creates several works through the bulk works endpoint, sending
+// them in batches of MaxBulkPutCodes. ORCID accepts or rejects each work
+// individually, so per-work failures are reported in the result rather than
+// as an error. The returned error is only set for batches that failed as a
+// whole; their works are also marked with that error in the result.
+func (c *Client) AddWorks(ctx context.Context, orcidID string, works []*Work) (*BulkWorkResult, error) {
+	result := &BulkWorkResult{Items: make([]BulkWorkItem, len(works))}
+	for i, work := range works {
+		result.Items[i].Work = work
+		if work == nil {
+			result.Items[i].Err = fmt.Errorf("work is required")
+		}
+	}
+
+	url := fmt.Sprintf("%s/%s/works", c.apiURL, orcidID)
+
+	var errs []error
+	for start := 0; start < len(works); start += MaxBulkPutCodes {
+		end := min(start+MaxBulkPutCodes, len(works))
+
+		var batch []int
+		request := &WorkBulk{}
+		for i := start; i < end; i++ {
+			if works[i] == nil {
+				continue
+			}
+			body := works[i].forWrite()
+			body.PutCode = 0
+			batch = append(batch, i)
+			request.Bulk = append(request.Bulk, &WorkBulkItem{Work: body})
+		}
+		if len(batch) == 0 {
+			continue
+		}
+
+		response, err := c.postWorksBulk(ctx, url, request)
+		if err != nil {
+			err = fmt.Errorf("works %d-%d: %w", start, end-1, err)
+			for _, i := range batch {
+				result.Items[i].Err = err
+			}
+			errs = append(errs, err)
+			continue
+		}
+
+		// ORCID answers with one item per submitted work, in order
+		for n, i := range batch {
+			switch {
+			case n >= len(response.Bulk) || response.Bulk[n] == nil:
+				result.Items[i].Err = fmt.Errorf("no result returned for work")
+			case response.Bulk[n].Error != nil:
+				result.Items[i].Err = bulkItemError(response.Bulk[n].Error)
+			case response.Bulk[n].Work != nil && response.Bulk[n].Work.PutCode > 0:
+				result.Items[i].PutCode = response.Bulk[n].Work.PutCode
+			default:
+				result.Items[i].Err = fmt.Errorf("no put-code returned for work")
+			}
+		}
+	}
+
+	return result, errors.Join(errs...)
+}
+
+func (c *Client) postWorksBulk(ctx context.Context, url string, request *WorkBulk) (*WorkBulk, error) {
+	body, err := c.marshalRequest(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var response WorkBulk
+	if err := c.decodeResponse(resp.Body, &response); err != nil {
+		return nil, err
+	}
+	return &response, nil
+}
+
+// createEndpoints maps the resource segment of a path to the endpoint used to
+// create an item of that type. Section paths such as "works" and item paths
+// such as "work/12345" both map to the singular endpoint.
+var createEndpoints = map[string]string{
+	"work":                 "work",
+	"works":                "work",
+	"education":            "education",
+	"educations":           "education",
+	"employment":           "employment",
+	"employments":          "employment",
+	"funding":              "funding",
+	"fundings":             "funding",
+	"peer-review":          "peer-review",
+	"peer-reviews":         "peer-review",
+	"distinction":          "distinction",
+	"distinctions":         "distinction",
+	"invited-position":     "invited-position",
+	"invited-positions":    "invited-position",
+	"membership":           "membership",
+	"memberships":          "membership",
+	"qualification":        "qualification",
+	"qualifications":       "qualification",
+	"service":              "service",
+	"services":             "service",
+	"research-resource":    "research-resource",
+	"research-resources":   "research-resource",
+	"other-names":          "other-names",
+	"researcher-urls":      "researcher-urls",
+	"keywords":             "keywords",
+	"external-identifiers": "external-identifiers",
+	"address":              "address",
+}
+
+// PostByPath creates an item of the type named by path and returns the
+// put-code ORCID assigned to it. It accepts the same kinds of paths as
+// GetByPath, so stored section or item paths can be reused for writes:
+//
+//   - "/0000-0003-1401-2056/works" -> posts to /0000-0003-1401-2056/work
+//   - "/0000-0003-1401-2056/education/12345" -> posts to /0000-0003-1401-2056/education
+//
+// When body is one of the activity types with write support, server-assigned
+// fields are removed as in AddWork.
+func (c *Client) PostByPath(ctx context.Context, path Path, body interface{}) (int64, error) {
+	if body == nil {
+		return 0, fmt.Errorf("body is required")
+	}
+
+	parts := strings.Split(strings.Trim(string(path), "/"), "/")
+	if len(parts) < 2 || parts[0] == "" {
+		return 0, fmt.Errorf("path must name a resource type: %s", path)
+	}
+
+	endpoint, ok := createEndpoints[parts[1]]
+	if !ok {
+		return 0, fmt.Errorf("unsupported resource type in path: %s", path)
+	}
+	if funding, ok := body.(*FundingSummary); ok {
+		if err := validateFunding(funding); err != nil {
+			return 0, err
+		}
+	}
+
+	url := fmt.Sprintf("%s/%s/%s", c.apiURL, parts[0], endpoint)
+	return c.createItem(ctx, url, createBody(body))
+}
+
+// createBody strips server-assigned fields from the types that support it.
+func createBody(body interface{}) interface{} {
+	switch v := body.(type) {
+	case *Work:
+		w := v.forWrite()
+		w.PutCode = 0
+		return w
+	case *EducationSummary:
+		e := v.forWrite()
+		e.PutCode = 0
+		return e
+	case *EmploymentSummary:
+		e := v.forWrite()
+		e.PutCode = 0
+		return e
+	case *FundingSummary:
+		f := v.forWrite()
+		f.PutCode = 0
+		return f
+	}
+	return body
+}
+
+// createItem posts v to url and returns the put-code from the Location
+// header of the response.
+func (c *Client) createItem(ctx context.Context, url string, v interface{}) (int64, error) {
+	body, err := c.marshalRequest(ctx, v)
+	if err != nil {
+		return 0, err
+	}
+
+	resp, err := c.doRequest(ctx, http.MethodPost, url, body)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+
+	return parsePutCodeFromLocation(resp.Header.Get("Location"))
+}
+
+// UpdateWork replaces the work with the given put-code and returns the work as
+// stored by ORCID. ORCID requires the put-code in the body to match the URL,
+// so a work carrying a different put-code is rejected without contacting the
+// API; a work without a put-code is sent with putCode filled in.
+//
+// With WithCache, a work read with GetWork whose response had an ETag is
+// updated with If-Match, so that the update fails with an *APIError for HTTP
+// 412 instead of overwriting changes made since it was read.
+func (c *Client) UpdateWork(ctx context.Context, orcidID string, putCode int64, work *Work) (*Work, error) {
+	if work == nil {
+		return nil, fmt.Errorf("work is required")
+	}
+	if work.PutCode != 0 && work.PutCode != putCode {
+		return nil, fmt.Errorf("work put-code %d does not match put-code %d", work.PutCode, putCode)
+	}
+
+	body := work.forWrite()
+	body.PutCode = putCode
+
+	var updated Work
+	url := fmt.Sprintf("%s/%s/work/%d", c.apiURL, orcidID, putCode)
+	if err := c.updateItem(ctx, url, body, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// updateItem puts v to url and decodes the server's copy of the item into out.
+// The ETag cached for the item, if any, is sent as If-Match.
+func (c *Client) updateItem(ctx context.Context, url string, v interface{}, out interface{}) error {
+	body, err := c.marshalRequest(ctx, v)
+	if err != nil {
+		return err
+	}
+	contentType, err := c.contentTypeFor(ctx)
+	if err != nil {
+		return err
+	}
+
+	resp, err := c.doRequestHeader(ctx, http.MethodPut, url, body, c.ifMatchHeader(ctx, contentType, url))
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	c.forgetValidators(contentType, url)
+
+	return c.decodeResponse(resp.Body, out)
+}
+
+// DeleteWork removes the work with the given put-code. If the work does not
+// exist the returned error wraps ErrNotFound, so callers that want deletion
+// to be idempotent can ignore it with errors.Is.
+func (c *Client) DeleteWork(ctx context.Context, orcidID string, putCode int64) error {
+	url := fmt.Sprintf("%s/%s/work/%d", c.apiURL, orcidID, putCode)
+	return c.deleteItem(ctx, url)
+}
+
+// UpdateEmployment replaces the employment with the given put-code and
+// returns the employment as stored by ORCID, including its new
+// last-modified date. The put-code rules are the same as for UpdateWork.
+func (c *Client) UpdateEmployment(ctx context.Context, orcidID string, putCode int64, employment *EmploymentSummary) (*EmploymentSummary, error) {
+	if employment == nil {
+		return nil, fmt.Errorf("employment is required")
+	}
+	if employment.PutCode != 0 && employment.PutCode != putCode {
+		return nil, fmt.Errorf("employment put-code %d does not match put-code %d", employment.PutCode, putCode)
+	}
+
+	body := employment.forWrite()
+	body.PutCode = putCode
+
+	var updated EmploymentSummary
+	url := fmt.Sprintf("%s/%s/employment/%d", c.apiURL, orcidID, putCode)
+	if err := c.updateItem(ctx, url, body, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteEmployment removes the employment with the given put-code. As with
+// DeleteWork, a missing employment is reported with ErrNotFound.
+func (c *Client) DeleteEmployment(ctx context.Context, orcidID string, putCode int64) error {
+	url := fmt.Sprintf("%s/%s/employment/%d", c.apiURL, orcidID, putCode)
+	return c.deleteItem(ctx, url)
+}
+
+// forWrite returns a copy of the employment without server-assigned fields.
+func (e *EmploymentSummary) forWrite() *EmploymentSummary {
+	body := *e
+	body.CreatedDate = nil
+	body.LastModifiedDate = nil
+	body.Source = nil
+	body.Path = ""
+	return &body
+}
+
+// AddFunding creates a funding item on the record through the member API and
+// returns the put-code ORCID assigned to it. ORCID requires fundings to carry
+// at least one external ID, such as a grant number, so a funding without one
+// is rejected without contacting the API.
+func (c *Client) AddFunding(ctx context.Context, orcidID string, funding *FundingSummary) (int64, error) {
+	if err := validateFunding(funding); err != nil {
+		return 0, err
+	}
+
+	body := funding.forWrite()
+	body.PutCode = 0
+
+	url := fmt.Sprintf("%s/%s/funding", c.apiURL, orcidID)
+	return c.createItem(ctx, url, body)
+}
+
+// UpdateFunding replaces the funding with the given put-code and returns the
+// funding as stored by ORCID. It applies the same checks as AddFunding and
+// the same put-code rules as UpdateWork.
+func (c *Client) UpdateFunding(ctx context.Context, orcidID string, putCode int64, funding *FundingSummary) (*FundingSummary, error) {
+	if err := validateFunding(funding); err != nil {
+		return nil, err
+	}
+	if funding.PutCode != 0 && funding.PutCode != putCode {
+		return nil, fmt.Errorf("funding put-code %d does not match put-code %d", funding.PutCode, putCode)
+	}
+
+	body := funding.forWrite()
+	body.PutCode = putCode
+
+	var updated FundingSummary
+	url := fmt.Sprintf("%s/%s/funding/%d", c.apiURL, orcidID, putCode)
+	if err := c.updateItem(ctx, url, body, &updated); err != nil {
+		return nil, err
+	}
+
+	return &updated, nil
+}
+
+// DeleteFunding removes the funding with the given put-code. A missing
+// funding is reported with ErrNotFound.
+func (c *Client) DeleteFunding(ctx context.Context, orcidID string, putCode int64) error {
+	url := fmt.Sprintf("%s/%s/funding/%d", c.apiURL, orcidID, putCode)
+	return c.deleteItem(ctx, url)
+}
+
+func validateFunding(funding *FundingSummary) error {
+	if funding == nil {
+		return fmt.Errorf("funding is required")
+	}
+	if funding.ExternalIDs != nil {
+		for _, id := range funding.ExternalIDs.ExternalID {
+			if id != nil && id.ExternalIDType != "" && id.ExternalIDValue != "" {
+				return nil
+			}
+		}
+	}
+	return fmt.Errorf("funding requires at least one external ID with a type and value")
+}
+
+// forWrite returns a copy of the funding without server-assigned fields.
+func (f *FundingSummary) forWrite() *FundingSummary {
+	body := *f
+	body.CreatedDate = nil
+	body.LastModifiedDate = nil
+	body.Source = nil
+	body.Path = ""
+	return &body
+}
+
+func (c *Client) deleteItem(ctx context.Context, url string) error {
+	resp, err := c.doRequest(ctx, http.MethodDelete, url, nil)
+	if err != nil {
+		return err
+	}
+	resp.Body.Close()
+	return nil
+}
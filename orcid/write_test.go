@@ -0,0 +1,700 @@
+package orcid
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParsePutCodeFromLocation(t *testing.T) {
+	tests := []struct {
+		name     string
+		location string
+		expected int64
+		wantErr  bool
+	}{
+		{
+			name:     "Full URL",
+			location: "https://api.orcid.org/v3.0/0000-0002-1825-0097/work/12345",
+			expected: 12345,
+		},
+		{
+			name:     "Trailing slash",
+			location: "https://api.orcid.org/v3.0/0000-0002-1825-0097/work/12345/",
+			expected: 12345,
+		},
+		{
+			name:     "Query string",
+			location: "https://api.orcid.org/v3.0/0000-0002-1825-0097/work/12345?lang=en",
+			expected: 12345,
+		},
+		{
+			name:     "Fragment",
+			location: "https://api.orcid.org/v3.0/0000-0002-1825-0097/work/12345#top",
+			expected: 12345,
+		},
+		{
+			name:     "Relative path",
+			location: "/v3.0/0000-0002-1825-0097/education/678",
+			expected: 678,
+		},
+		{
+			name:     "Surrounding whitespace",
+			location: "  https://api.orcid.org/v3.0/0000-0002-1825-0097/funding/42 ",
+			expected: 42,
+		},
+		{
+			name:     "Empty header",
+			location: "",
+			wantErr:  true,
+		},
+		{
+			name:     "Non-numeric put-code",
+			location: "https://api.orcid.org/v3.0/0000-0002-1825-0097/work/abc",
+			wantErr:  true,
+		},
+		{
+			name:     "Only slashes",
+			location: "///",
+			wantErr:  true,
+		},
+		{
+			name:     "Negative put-code",
+			location: "https://api.orcid.org/v3.0/0000-0002-1825-0097/work/-5",
+			wantErr:  true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			putCode, err := parsePutCodeFromLocation(tt.location)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("Expected error, got put-code %d", putCode)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Unexpected error: %v", err)
+			}
+			if putCode != tt.expected {
+				t.Errorf("Expected put-code %d, got %d", tt.expected, putCode)
+			}
+		})
+	}
+}
+
+func TestAddWork(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected method %s, got %s", http.MethodPost, r.Method)
+		}
+		if r.URL.Path != "/v3.0/0000-0002-1825-0097/work" {
+			t.Errorf("Expected path %s, got %s", "/v3.0/0000-0002-1825-0097/work", r.URL.Path)
+		}
+		if r.Header.Get("Content-Type") != "application/json" {
+			t.Errorf("Expected Content-Type %s, got %s", "application/json", r.Header.Get("Content-Type"))
+		}
+
+		var work Work
+		if err := json.NewDecoder(r.Body).Decode(&work); err != nil {
+			t.Errorf("Unexpected error decoding body: %v", err)
+		}
+		if work.Title == nil || work.Title.Title.Value != "New Work" {
+			t.Errorf("Expected title %s in request body", "New Work")
+		}
+
+		w.Header().Set("Location", "https://api.orcid.org/v3.0/0000-0002-1825-0097/work/98765")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+	ctx := context.Background()
+
+	putCode, err := client.AddWork(ctx, "0000-0002-1825-0097", &Work{
+		Title: &Title{Title: &TitleValue{Value: "New Work"}},
+		Type:  "journal-article",
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if putCode != 98765 {
+		t.Errorf("Expected put-code %d, got %d", 98765, putCode)
+	}
+}
+
+func TestAddWorkValidationError(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusBadRequest)
+		w.Write([]byte(`{"response-code": 400, "developer-message": "Invalid work type", "error-code": 9001}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+	ctx := context.Background()
+
+	_, err := client.AddWork(ctx, "0000-0002-1825-0097", &Work{Type: "bogus"})
+	if err == nil {
+		t.Fatal("Expected error for 400 response")
+	}
+	if !strings.Contains(err.Error(), "400") || !strings.Contains(err.Error(), "Invalid work type") {
+		t.Errorf("Expected error to include status and ORCID message, got: %v", err)
+	}
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt, got %d", attempts)
+	}
+}
+
+func TestAddWorkNotRetriedOnServerError(t *testing.T) {
+	attempts := 0
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+
+	_, err := client.AddWork(context.Background(), "0000-0002-1825-0097", &Work{Type: "book"})
+	if err == nil {
+		t.Fatal("Expected error for 500 response")
+	}
+	if attempts != 1 {
+		t.Errorf("Expected POST not to be retried, got %d attempts", attempts)
+	}
+}
+
+func TestUpdateWork(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPut {
+			t.Errorf("Expected method %s, got %s", http.MethodPut, r.Method)
+		}
+		if r.URL.Path != "/v3.0/0000-0002-1825-0097/work/12345" {
+			t.Errorf("Expected path %s, got %s", "/v3.0/0000-0002-1825-0097/work/12345", r.URL.Path)
+		}
+
+		var work Work
+		if err := json.NewDecoder(r.Body).Decode(&work); err != nil {
+			t.Errorf("Unexpected error decoding body: %v", err)
+		}
+		if work.PutCode != 12345 {
+			t.Errorf("Expected put-code %d in request body, got %d", 12345, work.PutCode)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{
+			"put-code": 12345,
+			"last-modified-date": {"value": 1609459200000},
+			"title": {"title": {"value": "Updated Work"}},
+			"type": "journal-article"
+		}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+	ctx := context.Background()
+
+	work := &Work{
+		Title: &Title{Title: &TitleValue{Value: "Updated Work"}},
+		Type:  "journal-article",
+	}
+	updated, err := client.UpdateWork(ctx, "0000-0002-1825-0097", 12345, work)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if updated.PutCode != 12345 {
+		t.Errorf("Expected put-code %d, got %d", 12345, updated.PutCode)
+	}
+	if updated.LastModifiedDate == nil || updated.LastModifiedDate.Value.IsZero() {
+		t.Error("Expected last-modified-date from the server copy")
+	}
+	if work.PutCode != 0 {
+		t.Error("Expected caller's work not to be modified")
+	}
+}
+
+func TestUpdateWorkIfMatch(t *testing.T) {
+	etag := `"v2"`
+	var ifMatch []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			w.Header().Set("ETag", etag)
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"put-code": 12345, "type": "journal-article"}`))
+			return
+		}
+		ifMatch = append(ifMatch, r.Header.Get("If-Match"))
+		if match := r.Header.Get("If-Match"); match != "" && match != `"v3"` {
+			w.WriteHeader(http.StatusPreconditionFailed)
+			return
+		}
+		w.WriteHeader(http.StatusOK)
+		w.Write([]byte(`{"put-code": 12345, "type": "journal-article"}`))
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+		WithCache(NewMemoryCache(10)),
+	)
+	ctx := context.Background()
+
+	work, err := client.GetWork(ctx, "0000-0002-1825-0097", "12345")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The work changed after it was read, so the update is rejected
+	etag = `"v3"`
+	_, err = client.UpdateWork(ctx, "0000-0002-1825-0097", 12345, work)
+	var apiErr *APIError
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusPreconditionFailed {
+		t.Errorf("Expected an *APIError for HTTP 412, got %v", err)
+	}
+
+	// Once the work is read again, the update goes through
+	if work, err = client.GetWork(ctx, "0000-0002-1825-0097", "12345"); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := client.UpdateWork(ctx, "0000-0002-1825-0097", 12345, work); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	// The ETag is stale after the update and is not sent again
+	if _, err := client.UpdateWork(ctx, "0000-0002-1825-0097", 12345, work); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Join(ifMatch, ",") != `"v2","v3",` {
+		t.Errorf("Expected If-Match \"v2\", \"v3\" and none, got %q", ifMatch)
+	}
+}
+
+func TestUpdateWorkPutCodeMismatch(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected no request to be made")
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+
+	_, err := client.UpdateWork(context.Background(), "0000-0002-1825-0097", 12345, &Work{PutCode: 999})
+	if err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("Expected put-code mismatch error, got: %v", err)
+	}
+}
+
+func TestDeleteWork(t *testing.T) {
+	deleted := map[string]bool{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete {
+			t.Errorf("Expected method %s, got %s", http.MethodDelete, r.Method)
+		}
+		if deleted[r.URL.Path] {
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"response-code": 404, "developer-message": "No entity found"}`))
+			return
+		}
+		deleted[r.URL.Path] = true
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+	ctx := context.Background()
+
+	if err := client.DeleteWork(ctx, "0000-0002-1825-0097", 12345); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if !deleted["/v3.0/0000-0002-1825-0097/work/12345"] {
+		t.Error("Expected DELETE request to the work path")
+	}
+
+	err := client.DeleteWork(ctx, "0000-0002-1825-0097", 12345)
+	if !errors.Is(err, ErrNotFound) {
+		t.Errorf("Expected ErrNotFound for an already deleted work, got: %v", err)
+	}
+}
+
+func TestAddWorks(t *testing.T) {
+	var batchSizes []int
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected method %s, got %s", http.MethodPost, r.Method)
+		}
+		if r.URL.Path != "/v3.0/0000-0002-1825-0097/works" {
+			t.Errorf("Expected path %s, got %s", "/v3.0/0000-0002-1825-0097/works", r.URL.Path)
+		}
+
+		var request WorkBulk
+		if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+			t.Errorf("Unexpected error decoding body: %v", err)
+		}
+		batchSizes = append(batchSizes, len(request.Bulk))
+
+		// Works titled "bad" are rejected, the rest get their title as put-code
+		var response WorkBulk
+		for _, item := range request.Bulk {
+			title := item.Work.Title.Title.Value
+			if title == "bad" {
+				response.Bulk = append(response.Bulk, &WorkBulkItem{Error: &OrcidError{
+					ResponseCode:     400,
+					DeveloperMessage: "Invalid work type",
+					ErrorCode:        9001,
+				}})
+				continue
+			}
+			putCode, _ := strconv.ParseInt(title, 10, 64)
+			response.Bulk = append(response.Bulk, &WorkBulkItem{Work: &Work{PutCode: putCode}})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusOK)
+		json.NewEncoder(w).Encode(response)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+	ctx := context.Background()
+
+	works := make([]*Work, 150)
+	for i := range works {
+		title := strconv.Itoa(i + 1)
+		if i%50 == 7 {
+			title = "bad"
+		}
+		works[i] = &Work{Title: &Title{Title: &TitleValue{Value: title}}}
+	}
+
+	result, err := client.AddWorks(ctx, "0000-0002-1825-0097", works)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(batchSizes) != 2 || batchSizes[0] != 100 || batchSizes[1] != 50 {
+		t.Errorf("Expected batches of [100 50], got %v", batchSizes)
+	}
+	if len(result.Items) != len(works) {
+		t.Fatalf("Expected %d items, got %d", len(works), len(result.Items))
+	}
+
+	for i, item := range result.Items {
+		if item.Work != works[i] {
+			t.Errorf("Expected item %d to hold the submitted work", i)
+		}
+		if i%50 == 7 {
+			if item.Err == nil || !strings.Contains(item.Err.Error(), "9001") {
+				t.Errorf("Expected error 9001 for item %d, got %v", i, item.Err)
+			}
+			continue
+		}
+		if item.Err != nil {
+			t.Errorf("Unexpected error for item %d: %v", i, item.Err)
+		}
+		if item.PutCode != int64(i+1) {
+			t.Errorf("Expected put-code %d for item %d, got %d", i+1, i, item.PutCode)
+		}
+	}
+
+	if failed := result.Failed(); len(failed) != 3 {
+		t.Errorf("Expected 3 failed items, got %d", len(failed))
+	}
+}
+
+func TestAddEducation(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected method %s, got %s", http.MethodPost, r.Method)
+		}
+		if r.URL.Path != "/v3.0/0000-0002-1825-0097/education" {
+			t.Errorf("Expected path %s, got %s", "/v3.0/0000-0002-1825-0097/education", r.URL.Path)
+		}
+
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Unexpected error decoding body: %v", err)
+		}
+		for _, field := range []string{"put-code", "created-date", "last-modified-date", "source", "path"} {
+			if _, ok := body[field]; ok {
+				t.Errorf("Expected read-only field %s to be omitted", field)
+			}
+		}
+		if _, ok := body["role-title"]; !ok {
+			t.Errorf("Expected role-title in request body")
+		}
+
+		w.Header().Set("Location", "https://api.orcid.org/v3.0/0000-0002-1825-0097/education/4321")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+	ctx := context.Background()
+
+	// An education copied from a fetched record still carries server fields
+	edu := &EducationSummary{
+		PutCode:     1111,
+		CreatedDate: &Date{Value: time.UnixMilli(1700000000000)},
+		Source:      &Source{SourceName: &SourceName{Value: "Example University"}},
+		RoleTitle:   "PhD",
+		Organization: &Organization{
+			Name: "Example University",
+		},
+		Path: "/0000-0002-1825-0097/education/1111",
+	}
+
+	putCode, err := client.AddEducation(ctx, "0000-0002-1825-0097", edu)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if putCode != 4321 {
+		t.Errorf("Expected put-code %d, got %d", 4321, putCode)
+	}
+	if edu.PutCode != 1111 || edu.Source == nil {
+		t.Error("Expected the caller's education to be left unchanged")
+	}
+}
+
+func TestUpdateAndDeleteEmployment(t *testing.T) {
+	var methods []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/v3.0/0000-0002-1825-0097/employment/777" {
+			t.Errorf("Expected path %s, got %s", "/v3.0/0000-0002-1825-0097/employment/777", r.URL.Path)
+		}
+		methods = append(methods, r.Method)
+
+		switch r.Method {
+		case http.MethodPut:
+			var employment EmploymentSummary
+			if err := json.NewDecoder(r.Body).Decode(&employment); err != nil {
+				t.Errorf("Unexpected error decoding body: %v", err)
+			}
+			if employment.PutCode != 777 {
+				t.Errorf("Expected put-code %d in request body, got %d", 777, employment.PutCode)
+			}
+			if employment.LastModifiedDate != nil {
+				t.Error("Expected last-modified-date to be omitted from the request")
+			}
+
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{
+				"put-code": 777,
+				"last-modified-date": {"value": 1609459200000},
+				"role-title": "Professor"
+			}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+	ctx := context.Background()
+
+	updated, err := client.UpdateEmployment(ctx, "0000-0002-1825-0097", 777, &EmploymentSummary{
+		RoleTitle:        "Professor",
+		LastModifiedDate: &Date{Value: time.UnixMilli(1500000000000)},
+	})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if updated.LastModifiedDate == nil || !updated.LastModifiedDate.Value.Equal(time.UnixMilli(1609459200000)) {
+		t.Errorf("Expected last-modified-date from the server copy, got %+v", updated.LastModifiedDate)
+	}
+
+	if err := client.DeleteEmployment(ctx, "0000-0002-1825-0097", 777); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	if len(methods) != 2 || methods[0] != http.MethodPut || methods[1] != http.MethodDelete {
+		t.Errorf("Expected PUT then DELETE, got %v", methods)
+	}
+
+	_, err = client.UpdateEmployment(ctx, "0000-0002-1825-0097", 777, &EmploymentSummary{PutCode: 1})
+	if err == nil || !strings.Contains(err.Error(), "does not match") {
+		t.Errorf("Expected put-code mismatch error, got: %v", err)
+	}
+}
+
+func TestFundingCRUD(t *testing.T) {
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests = append(requests, r.Method+" "+r.URL.Path)
+
+		switch r.Method {
+		case http.MethodPost:
+			w.Header().Set("Location", "https://api.orcid.org/v3.0/0000-0002-1825-0097/funding/555")
+			w.WriteHeader(http.StatusCreated)
+		case http.MethodPut:
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusOK)
+			w.Write([]byte(`{"put-code": 555, "type": "grant", "last-modified-date": {"value": 1609459200000}}`))
+		case http.MethodDelete:
+			w.WriteHeader(http.StatusNoContent)
+		}
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+	ctx := context.Background()
+
+	funding := &FundingSummary{
+		Title: &Title{Title: &TitleValue{Value: "Research Grant"}},
+		Type:  "grant",
+		ExternalIDs: &ExternalIDs{ExternalID: []*ExternalID{
+			{ExternalIDType: "grant_number", ExternalIDValue: "ABC-123", ExternalIDRelationship: "self"},
+		}},
+	}
+
+	putCode, err := client.AddFunding(ctx, "0000-0002-1825-0097", funding)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if putCode != 555 {
+		t.Errorf("Expected put-code %d, got %d", 555, putCode)
+	}
+
+	updated, err := client.UpdateFunding(ctx, "0000-0002-1825-0097", putCode, funding)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if updated.PutCode != 555 || updated.LastModifiedDate == nil {
+		t.Errorf("Expected the server copy of the funding, got %+v", updated)
+	}
+
+	if err := client.DeleteFunding(ctx, "0000-0002-1825-0097", putCode); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	expected := []string{
+		"POST /v3.0/0000-0002-1825-0097/funding",
+		"PUT /v3.0/0000-0002-1825-0097/funding/555",
+		"DELETE /v3.0/0000-0002-1825-0097/funding/555",
+	}
+	if strings.Join(requests, "\n") != strings.Join(expected, "\n") {
+		t.Errorf("Expected requests %v, got %v", expected, requests)
+	}
+}
+
+func TestAddFundingRequiresExternalID(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("Expected no request to be made")
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+	ctx := context.Background()
+
+	for _, funding := range []*FundingSummary{
+		{Type: "grant"},
+		{Type: "grant", ExternalIDs: &ExternalIDs{ExternalID: []*ExternalID{{ExternalIDType: "grant_number"}}}},
+	} {
+		_, err := client.AddFunding(ctx, "0000-0002-1825-0097", funding)
+		if err == nil || !strings.Contains(err.Error(), "external ID") {
+			t.Errorf("Expected missing external ID error, got: %v", err)
+		}
+	}
+}
+
+func TestPostByPath(t *testing.T) {
+	var paths []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			t.Errorf("Expected method %s, got %s", http.MethodPost, r.Method)
+		}
+		paths = append(paths, r.URL.Path)
+
+		var body map[string]json.RawMessage
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Errorf("Unexpected error decoding body: %v", err)
+		}
+		if _, ok := body["put-code"]; ok {
+			t.Error("Expected put-code to be omitted")
+		}
+
+		w.Header().Set("Location", "https://api.orcid.org"+r.URL.Path+"/321")
+		w.WriteHeader(http.StatusCreated)
+	}))
+	defer server.Close()
+
+	client := NewClient(
+		WithAPIURL(server.URL+"/v3.0"),
+		WithBearerToken("test-token"),
+	)
+	ctx := context.Background()
+
+	tests := []struct {
+		path     Path
+		body     interface{}
+		expected string
+	}{
+		{"/0000-0002-1825-0097/works", &Work{Type: "book"}, "/v3.0/0000-0002-1825-0097/work"},
+		{"/0000-0002-1825-0097/education/12345", &EducationSummary{PutCode: 12345, RoleTitle: "PhD"}, "/v3.0/0000-0002-1825-0097/education"},
+		{"0000-0002-1825-0097/keywords", map[string]string{"content": "physics"}, "/v3.0/0000-0002-1825-0097/keywords"},
+	}
+	for _, tt := range tests {
+		putCode, err := client.PostByPath(ctx, tt.path, tt.body)
+		if err != nil {
+			t.Fatalf("Unexpected error for %s: %v", tt.path, err)
+		}
+		if putCode != 321 {
+			t.Errorf("Expected put-code %d for %s, got %d", 321, tt.path, putCode)
+		}
+	}
+	for i, tt := range tests {
+		if i >= len(paths) || paths[i] != tt.expected {
+			t.Errorf("Expected POST to %s for %s, got %v", tt.expected, tt.path, paths)
+		}
+	}
+
+	for _, path := range []Path{"/0000-0002-1825-0097", "/0000-0002-1825-0097/record", "/0000-0002-1825-0097/person"} {
+		if _, err := client.PostByPath(ctx, path, &Work{}); err == nil {
+			t.Errorf("Expected error for path %s", path)
+		}
+	}
+}